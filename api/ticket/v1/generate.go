@@ -0,0 +1,6 @@
+// Package ticketv1 holds the gRPC API definition and the code generated from
+// it. Regenerate after editing ticket.proto with go generate, which needs
+// protoc, protoc-gen-go and protoc-gen-go-grpc on PATH.
+package ticketv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative ticket/v1/ticket.proto
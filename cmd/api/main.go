@@ -1,171 +1,511 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"fmt"
-	"log"
+	"expvar"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	_ "github.com/lib/pq"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
-
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	ticketv1 "github.com/srgjo27/scalable_ticket/api/ticket/v1"
+	memorycache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/memory"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	rediscache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/redis"
+	grpcapi "github.com/srgjo27/scalable_ticket/internal/adapter/grpc"
 	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/metrics/prometheus"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
 	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/postgres"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/tracing"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
 	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/srgjo27/scalable_ticket/internal/platform/config"
 	"github.com/srgjo27/scalable_ticket/internal/platform/database"
+	"github.com/srgjo27/scalable_ticket/internal/platform/database/migrations"
+	"github.com/srgjo27/scalable_ticket/internal/platform/health"
+	"github.com/srgjo27/scalable_ticket/internal/platform/lifecycle"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"github.com/srgjo27/scalable_ticket/internal/platform/telemetry"
 )
 
-func loadEnv(filepath string) {
-	file, err := os.Open(filepath)
+// postgresConfig maps the loaded configuration onto the database package.
+func postgresConfig(cfg config.DatabaseConfig) database.Config {
+	return database.Config{
+		Host:            cfg.Host,
+		Port:            strconv.Itoa(cfg.Port),
+		User:            cfg.User,
+		Password:        cfg.Password,
+		DBName:          cfg.Name,
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnectRetries:  cfg.ConnectRetries,
+		RetryInterval:   cfg.RetryInterval,
+	}
+}
 
-	if err != nil {
-		log.Println("File .env tidak ditemukan, menggunakan variabel OS bawaan.")
-		return
+// corsConfig maps the loaded configuration onto the CORS middleware.
+func corsConfig(cfg config.CORSConfig) handler.CORSConfig {
+	return handler.CORSConfig{
+		AllowedOrigins: cfg.AllowedOrigins,
+		AllowedMethods: cfg.AllowedMethods,
+		AllowedHeaders: cfg.AllowedHeaders,
+		ExposedHeaders: cfg.ExposedHeaders,
+		MaxAge:         cfg.MaxAge,
 	}
+}
 
-	defer file.Close()
+// rateLimitPolicies maps the loaded configuration onto the rate limiter.
+func rateLimitPolicies(cfg config.RateLimitConfig) handler.RateLimitPolicies {
+	return handler.RateLimitPolicies{
+		BookingPerUserEvent: ports.RateLimit{Limit: cfg.BookingPerUserEvent, Window: cfg.BookingPerUserEventWindow},
+		BookingPerEvent:     ports.RateLimit{Limit: cfg.BookingPerEvent, Window: cfg.BookingPerEventWindow},
+		SeatReadsPerIP:      ports.RateLimit{Limit: cfg.SeatReadsPerIP, Window: cfg.SeatReadsPerIPWindow},
+		ClientIPHeader:      cfg.ClientIPHeader,
+	}
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+// grpcRateLimitPolicies maps the same configuration onto the gRPC server. A
+// proxy passes HTTP headers on as lower-case metadata keys.
+func grpcRateLimitPolicies(cfg config.RateLimitConfig) grpcapi.RateLimitPolicies {
+	return grpcapi.RateLimitPolicies{
+		BookingPerUserEvent: ports.RateLimit{Limit: cfg.BookingPerUserEvent, Window: cfg.BookingPerUserEventWindow},
+		BookingPerEvent:     ports.RateLimit{Limit: cfg.BookingPerEvent, Window: cfg.BookingPerEventWindow},
+		SeatReadsPerIP:      ports.RateLimit{Limit: cfg.SeatReadsPerIP, Window: cfg.SeatReadsPerIPWindow},
+		ClientIPKey:         strings.ToLower(cfg.ClientIPHeader),
+	}
+}
 
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
+// readinessTimeout bounds each /readyz check, so a hung dependency fails the
+// probe instead of stalling it.
+const readinessTimeout = 2 * time.Second
 
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
 
-			os.Setenv(key, value)
-		}
+func main() {
+	cfg, args, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		fatal("Failed to load configuration", "error", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Printf("Gagal membaca file .env: %v\n", err)
+	logger, err := logging.New(logging.Config{Level: cfg.Log.Level, Format: cfg.Log.Format}, os.Stderr)
+	if err != nil {
+		fatal("Failed to set up logging", "error", err)
 	}
-}
 
-func main() {
-	loadEnv(".env")
+	slog.SetDefault(logger)
+	slog.Info("Effective configuration", "config", cfg.String())
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			runMigrate(cfg, args[1:])
+		case "seed":
+			runSeed(cfg)
+		default:
+			fatal("Unknown command (expected migrate or seed)", "command", args[0])
+		}
 
-	dbHost := os.Getenv("DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
+		return
 	}
 
-	dbPort := os.Getenv("DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), telemetry.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		ServiceName:  cfg.Tracing.ServiceName,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		File:         cfg.Tracing.File,
+	})
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
 	}
 
-	dbUser := os.Getenv("DB_USER")
-	if dbUser == "" {
-		dbUser = "postgres"
-	}
+	// Components stop in reverse order of registration: HTTP first, then
+	// gRPC, then the workers, then the connections they use, and the tracer last so it
+	// flushes every span.
+	app := lifecycle.NewManager()
+	app.OnStop("tracing", shutdownTracing)
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	metrics := prometheus.NewMetrics(registry)
+	checker := health.NewChecker(readinessTimeout)
+
+	var seatRepo ports.SeatRepository
+	var bookingRepo ports.BookingRepository
+	var seatAuditRepo ports.SeatAuditRepository
+	var eventRepo ports.EventRepository
+	var holdRepo ports.HoldRepository
+
+	switch cfg.StorageDriver {
+	case "postgres":
+		db, err := database.NewPostgresDB(postgresConfig(cfg.Database))
+
+		if err != nil {
+			fatal("Failed to connect to db after retries", "error", err)
+		}
 
-	dbPassword := os.Getenv("DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = ""
-	}
+		app.OnStop("postgres", func(context.Context) error { return db.Close() })
 
-	dbName := os.Getenv("DB_NAME")
-	if dbName == "" {
-		dbName = "scalable_ticket"
-	}
+		registry.MustRegister(collectors.NewDBStatsCollector(db, cfg.Database.Name))
+
+		all, err := database.LoadMigrations(migrations.FS)
+		if err != nil {
+			fatal("Failed to load migrations", "error", err)
+		}
 
-	dbConfig := database.Config{
-		Host:     dbHost,
-		Port:     dbPort,
-		User:     dbUser,
-		Password: dbPassword,
-		DBName:   dbName,
+		checker.Add("database", db.PingContext)
+		checker.Add("migrations", database.NewMigrator(db, all).CheckUpToDate)
+
+		seatRepo = postgres.NewSeatRepository(db)
+		bookingRepo = postgres.NewBookingRepository(db)
+		seatAuditRepo = postgres.NewSeatAuditRepository(db)
+		eventRepo = postgres.NewEventRepository(db)
+		holdRepo = postgres.NewHoldRepository(db)
+	case "memory":
+		slog.Info("Using in-memory storage seeded with demo data (lost on restart)")
+
+		store := memory.NewStore()
+		if err := memory.SeedDemo(store); err != nil {
+			fatal("Failed to seed in-memory storage", "error", err)
+		}
+
+		seatRepo = memory.NewSeatRepository(store)
+		bookingRepo = memory.NewBookingRepository(store)
+		seatAuditRepo = memory.NewSeatAuditRepository(store)
+		eventRepo = memory.NewEventRepository(store)
+		holdRepo = memory.NewHoldRepository(store)
 	}
 
-	db, err := database.NewPostgresDB(dbConfig)
+	seatRepo = tracing.NewSeatRepository(seatRepo)
+	bookingRepo = tracing.NewBookingRepository(bookingRepo)
+	seatAuditRepo = tracing.NewSeatAuditRepository(seatAuditRepo)
+	eventRepo = tracing.NewEventRepository(eventRepo)
+	holdRepo = tracing.NewHoldRepository(holdRepo)
+
+	var seatCache ports.SeatCache = noop.NewSeatCache()
+	var cache ports.Cache = noop.NewCache()
+	var reservationStore ports.SeatReservationStore
+	var rateLimiter ports.RateLimiter = noop.NewRateLimiter()
+
+	switch cfg.CacheDriver {
+	case "redis":
+		redisAddr := net.JoinHostPort(cfg.Redis.Host, strconv.Itoa(cfg.Redis.Port))
+		slog.Info("Connecting to Redis", "addr", redisAddr)
+
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     redisAddr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+			// One dial per attempt, so while Redis is down each call fails
+			// fast and the request falls back instead of stalling.
+			DialerRetries: 1,
+		})
+		redisClient.AddHook(metrics.RedisHook())
+		redisClient.AddHook(tracing.RedisHook())
+		app.OnStop("redis", func(context.Context) error { return redisClient.Close() })
+
+		// The adapters are used even if Redis is down now: each call falls
+		// back on its own (seats from Postgres, rate limits open) and the
+		// client reconnects once Redis answers again.
+		if err := redisClient.Ping(context.Background()).Err(); err != nil {
+			slog.Warn("Redis unavailable at startup, serving seats straight from Postgres without rate limits until it answers", "error", err)
+		} else {
+			slog.Info("Redis connected")
+		}
 
-	if err != nil {
-		log.Fatalf("Failed to connect to db after retries: %v", err)
+		checker.Add("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+		seatCache = rediscache.NewSeatCache(redisClient)
+		cache = rediscache.NewCache(redisClient)
+		reservationStore = rediscache.NewReservationStore(redisClient)
+		rateLimiter = rediscache.NewRateLimiter(redisClient)
+	case "memory":
+		slog.Info("Using in-memory cache (not shared between replicas)")
+		seatCache = memorycache.NewSeatCache()
+		cache = memorycache.NewCache()
+		rateLimiter = memorycache.NewRateLimiter()
+	case "none":
+		slog.Info("Caching and rate limiting disabled")
 	}
 
-	redisHost := os.Getenv("REDIS_HOST")
-	if redisHost == "" {
-		redisHost = "localhost"
+	bookingService := services.NewBookingService(seatRepo, bookingRepo, seatCache, cache)
+	bookingService.Configure(services.BookingConfig{
+		HoldDuration:      cfg.Booking.HoldDuration,
+		CleanupInterval:   cfg.Booking.CleanupInterval,
+		ReconcileInterval: cfg.Booking.ReconcileInterval,
+	})
+	bookingService.SetMetrics(metrics)
+	if reservationStore != nil {
+		bookingService.EnableReservationMode(eventRepo, reservationStore)
+	} else {
+		slog.Info("Redis reservation mode unavailable: all events use database locking")
 	}
 
-	redisPort := os.Getenv("REDIS_PORT")
-	if redisPort == "" {
-		redisPort = "6379"
+	adminService := services.NewAdminService(seatAuditRepo, eventRepo)
+
+	holdService := services.NewHoldService(holdRepo, seatRepo, seatCache, cache)
+	if reservationStore != nil {
+		holdService.SetReservationStore(reservationStore)
 	}
 
-	log.Printf("Connecting to Redis at %s:%s...", redisHost, redisPort)
+	checker.Add("cleanup_worker", bookingService.CheckCleanupWorker)
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: fmt.Sprintf("%s:%s", redisHost, redisPort),
-		DB:   0,
+	bookingHandler := handler.NewBookingHandler(bookingService)
+	bookingHandler.SetRateLimiter(handler.NewRateLimiter(rateLimiter, rateLimitPolicies(cfg.RateLimit)))
+	adminHandler := handler.NewAdminHandler(adminService)
+	holdHandler := handler.NewHoldHandler(holdService)
+	healthHandler := handler.NewHealthHandler(checker)
+
+	app.Go("booking_cleanup", bookingService.RunBackgroundCleanup)
+	app.Go("reservation_writer", func(ctx context.Context) {
+		bookingService.RunReservationWriter(ctx, cfg.Booking.ReservationWriters)
+	})
+	app.Go("reservation_reconciler", bookingService.RunReservationReconciler)
+	// Holds come due on minute boundaries at best, so the expiry sweep's
+	// cadence is fine for them too.
+	app.Go("hold_release", func(ctx context.Context) {
+		holdService.RunAutoRelease(ctx, cfg.Booking.CleanupInterval)
 	})
 
-	if err := redisClient.Ping(context.Background()).Err(); err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
-	}
-	log.Println("Redis connected successfully!")
+	mux := http.NewServeMux()
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
-	defer db.Close()
+	// api registers an API route with its handler timeout.
+	api := func(pattern string, timeout time.Duration, h http.HandlerFunc) {
+		mux.Handle(pattern, handler.Timeout(timeout)(h))
+	}
 
-	seatRepo := postgres.NewSeatRepository(db)
-	bookingRepo := postgres.NewBookingRepository(db)
+	api("POST /v1/events/{eventID}/bookings", cfg.HTTP.BookingTimeout, bookingHandler.CreateBooking)
+	api("GET /v1/events/{eventID}/seats", cfg.HTTP.RequestTimeout, bookingHandler.GetSeats)
+	api("GET /v1/events/{eventID}/seatmap", cfg.HTTP.RequestTimeout, bookingHandler.GetSeatMap)
+	api("GET /v1/bookings/{bookingID}", cfg.HTTP.RequestTimeout, bookingHandler.GetBooking)
+	api("GET /v1/users/me/bookings", cfg.HTTP.RequestTimeout, bookingHandler.ListMyBookings)
 
-	bookingService := services.NewBookingService(seatRepo, bookingRepo, redisClient)
+	// Admin routes need the admin token first.
+	requireAdmin := handler.RequireAdmin(cfg.HTTP.AdminToken)
+	if cfg.HTTP.AdminToken == "" {
+		slog.Warn("http.admin_token is not set, admin routes answer 403")
+	}
+	adminAPI := func(pattern string, timeout time.Duration, h http.HandlerFunc) {
+		mux.Handle(pattern, handler.Chain(h, requireAdmin, handler.Timeout(timeout)))
+	}
 
-	bookingHandler := handler.NewBookingHandler(bookingService)
+	adminAPI("GET /v1/admin/seats/{seatID}/history", cfg.HTTP.RequestTimeout, adminHandler.GetSeatHistory)
+	adminAPI("PUT /v1/admin/events/{eventID}/reservation-mode", cfg.HTTP.RequestTimeout, adminHandler.SetReservationMode)
+	adminAPI("POST /v1/admin/events/{eventID}/holds", cfg.HTTP.RequestTimeout, holdHandler.CreateHold)
+	adminAPI("GET /v1/admin/events/{eventID}/holds", cfg.HTTP.RequestTimeout, holdHandler.ListHolds)
+	adminAPI("GET /v1/admin/holds/{holdID}", cfg.HTTP.RequestTimeout, holdHandler.GetHold)
+	adminAPI("POST /v1/admin/holds/{holdID}/release", cfg.HTTP.RequestTimeout, holdHandler.ReleaseHold)
+	adminAPI("POST /v1/admin/holds/{holdID}/convert", cfg.HTTP.RequestTimeout, holdHandler.ConvertHold)
+
+	// The unversioned routes answer in their original format until the
+	// sunset date; new clients should use /v1.
+	deprecated := handler.Deprecated(cfg.HTTP.LegacySunsetDate())
+	legacy := func(pattern string, timeout time.Duration, h http.HandlerFunc) {
+		mux.Handle(pattern, handler.Chain(h, deprecated, handler.Timeout(timeout)))
+	}
 
-	go func() {
-		bookingService.RunBackgroundCleanup(context.Background())
-	}()
+	legacy("POST /bookings", cfg.HTTP.BookingTimeout, bookingHandler.LegacyCreateBooking)
+	legacy("GET /seats", cfg.HTTP.RequestTimeout, bookingHandler.LegacyGetSeats)
 
-	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.json", handler.OpenAPI)
 
-	mux.HandleFunc("/bookings", bookingHandler.CreateBooking)
+	mux.HandleFunc("GET /healthz", healthHandler.Healthz)
+	mux.HandleFunc("GET /readyz", healthHandler.Readyz)
 
-	mux.HandleFunc("/seats", bookingHandler.GetSeats)
+	// Outermost first. Recover sits inside logging and metrics so a panic is
+	// still recorded as a 500, and CORS answers preflights before the body
+	// limit applies.
+	routes := handler.Chain(handler.RouteErrors(mux),
+		tracing.Middleware,
+		handler.RequestLogging(logger),
+		metrics.InstrumentHTTP,
+		handler.Recover,
+		handler.CORS(corsConfig(cfg.CORS)),
+		handler.LimitBody(int64(cfg.HTTP.MaxBodyBytes)),
+	)
 
 	server := &http.Server{
-		Addr:         ":8080",
-		Handler:      mux,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  120 * time.Second,
+		Addr:         cfg.HTTP.Addr,
+		Handler:      routes,
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
 
-	go func() {
-		log.Println("Server starting on port :8080")
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server startup failed: %v", err)
+	serveErr := make(chan error, 3)
+
+	// Metrics and expvar counters stay off the public listener. The internal
+	// server is added first so it stops last and scrapes see the drain.
+	if cfg.HTTP.InternalAddr != "" {
+		internalMux := http.NewServeMux()
+		internalMux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		internalMux.Handle("GET /debug/vars", expvar.Handler())
+
+		internalServer := &http.Server{
+			Addr:         cfg.HTTP.InternalAddr,
+			Handler:      internalMux,
+			ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+			ReadTimeout:  cfg.HTTP.ReadTimeout,
+			WriteTimeout: cfg.HTTP.WriteTimeout,
+			IdleTimeout:  cfg.HTTP.IdleTimeout,
 		}
-	}()
+
+		app.Add("internal-http", func(context.Context) error {
+			ln, err := net.Listen("tcp", internalServer.Addr)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("Internal server starting", "addr", ln.Addr().String())
+			go func() {
+				if err := internalServer.Serve(ln); err != http.ErrServerClosed {
+					serveErr <- err
+				}
+			}()
+
+			return nil
+		}, internalServer.Shutdown)
+	}
+
+	if cfg.GRPC.Addr != "" {
+		// Same order as the HTTP chain: logging outermost, recover inside
+		// metrics so a panic still counts as INTERNAL.
+		grpcServer := gogrpc.NewServer(
+			gogrpc.StatsHandler(tracing.GRPCServerHandler()),
+			gogrpc.ChainUnaryInterceptor(
+				grpcapi.UnaryLogging(logger),
+				metrics.InstrumentGRPCUnary,
+				grpcapi.UnaryRecover,
+				grpcapi.UnaryAuth,
+			),
+			gogrpc.ChainStreamInterceptor(
+				grpcapi.StreamLogging(logger),
+				metrics.InstrumentGRPCStream,
+				grpcapi.StreamRecover,
+				grpcapi.StreamAuth,
+			),
+		)
+		ticketService := grpcapi.NewServer(bookingService, cfg.GRPC.WatchInterval)
+		ticketService.SetRateLimiter(rateLimiter, grpcRateLimitPolicies(cfg.RateLimit))
+		ticketv1.RegisterTicketServiceServer(grpcServer, ticketService)
+		reflection.Register(grpcServer)
+
+		app.Add("grpc", func(context.Context) error {
+			ln, err := net.Listen("tcp", cfg.GRPC.Addr)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("gRPC server starting", "addr", ln.Addr().String())
+			go func() {
+				if err := grpcServer.Serve(ln); err != nil {
+					serveErr <- err
+				}
+			}()
+
+			return nil
+		}, func(ctx context.Context) error {
+			slog.Info("Shutting down gRPC server")
+
+			// End the seat watches, which would otherwise hold GracefulStop
+			// open, then let unary calls finish.
+			ticketService.Drain()
+
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+
+			ctx, cancel := context.WithTimeout(ctx, cfg.GRPC.ShutdownTimeout)
+			defer cancel()
+
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				grpcServer.Stop()
+				return ctx.Err()
+			}
+		})
+	}
+
+	app.Add("http", func(context.Context) error {
+		// Listen before returning so a bad address fails Start.
+		ln, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("Server starting", "addr", ln.Addr().String())
+		go func() {
+			if err := server.Serve(ln); err != http.ErrServerClosed {
+				serveErr <- err
+			}
+		}()
+
+		return nil
+	}, func(ctx context.Context) error {
+		// Fail readiness first and keep serving while load balancers notice.
+		checker.Drain()
+		slog.Info("Draining before shutdown", "drain_delay", cfg.HTTP.DrainDelay.String())
+
+		select {
+		case <-time.After(cfg.HTTP.DrainDelay):
+		case <-ctx.Done():
+		}
+
+		slog.Info("Shutting down server")
+
+		ctx, cancel := context.WithTimeout(ctx, cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+
+		return server.Shutdown(ctx)
+	})
+
+	if err := app.Start(context.Background()); err != nil {
+		fatal("Failed to start", "error", err)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
-	<-quit
-	log.Println("Shutting down server...")
+	select {
+	case sig := <-quit:
+		slog.Info("Shutdown signal received", "signal", sig.String())
+	case err := <-serveErr:
+		slog.Error("Server failed, shutting down", "error", err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	if err := app.Stop(ctx); err != nil {
+		fatal("Shutdown did not complete cleanly", "error", err)
 	}
 
-	log.Println("Server exiting")
+	slog.Info("Server exiting")
 }
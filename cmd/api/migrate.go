@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/config"
+	"github.com/srgjo27/scalable_ticket/internal/platform/database"
+	"github.com/srgjo27/scalable_ticket/internal/platform/database/migrations"
+)
+
+const migrateUsage = `usage: main [flags] migrate <command>
+
+  up           apply every pending migration
+  down [n]     roll back the last n migrations (default 1)
+  status       list migrations and when they were applied
+  goto <v>     migrate up or down to version v (0 rolls back everything)`
+
+func migrateUsageExit() {
+	fmt.Fprintln(os.Stderr, migrateUsage)
+	os.Exit(2)
+}
+
+func runMigrate(cfg config.Config, args []string) {
+	if len(args) == 0 {
+		migrateUsageExit()
+	}
+
+	db := openMigrationDB(cfg)
+	defer db.Close()
+
+	all, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		fatal("Failed to load migrations", "error", err)
+	}
+
+	migrator := database.NewMigrator(db, all)
+	ctx := context.Background()
+
+	var changed []database.Migration
+	direction := "Applied"
+
+	switch args[0] {
+	case "up":
+		changed, err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fatal("Invalid step count", "steps", args[1])
+			}
+		}
+
+		direction = "Rolled back"
+		changed, err = migrator.Down(ctx, steps)
+	case "goto":
+		if len(args) < 2 {
+			migrateUsageExit()
+		}
+
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil || version < 0 {
+			fatal("Invalid version", "version", args[1])
+		}
+
+		direction = "Migrated"
+		changed, err = migrator.Goto(ctx, version)
+	case "status":
+		printMigrationStatus(ctx, migrator)
+		return
+	default:
+		migrateUsageExit()
+	}
+
+	for _, m := range changed {
+		slog.Info(direction, "version", m.Version, "name", m.Name)
+	}
+
+	if err != nil {
+		fatal("Migration failed", "error", err)
+	}
+
+	if len(changed) == 0 {
+		slog.Info("Schema already up to date")
+	}
+}
+
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		fatal("Failed to read migration status", "error", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	w.Flush()
+}
+
+func runSeed(cfg config.Config) {
+	db := openMigrationDB(cfg)
+	defer db.Close()
+
+	if err := database.Seed(context.Background(), db, migrations.Seed); err != nil {
+		fatal("Seeding failed", "error", err)
+	}
+
+	slog.Info("Demo data seeded")
+}
+
+func openMigrationDB(cfg config.Config) *sql.DB {
+	db, err := database.NewPostgresDB(postgresConfig(cfg.Database))
+	if err != nil {
+		fatal("Failed to connect to db after retries", "error", err)
+	}
+
+	return db
+}
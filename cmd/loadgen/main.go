@@ -0,0 +1,218 @@
+// Command loadgen simulates an on-sale against a running API. Virtual users
+// fetch the available seats, think, try to book seats picked by a selection
+// strategy and then either complete payment or abandon their hold.
+//
+//	go run ./cmd/loadgen -users 500 -strategy hot-seat -pay-rate 0.7
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type config struct {
+	Addr         string
+	EventID      string
+	Users        int
+	SeatsPerUser int
+	Strategy     string
+	HotSeats     int
+	Think        time.Duration
+	PayRate      float64
+	PayPath      string
+	Attempts     int
+	Ramp         time.Duration
+	Duration     time.Duration
+	Interval     time.Duration
+	Timeout      time.Duration
+	Output       string
+	Seed         int64
+}
+
+func parseFlags() config {
+	var cfg config
+
+	flag.StringVar(&cfg.Addr, "addr", "http://localhost:8080", "base URL of the API")
+	flag.StringVar(&cfg.EventID, "event", "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22", "event to put on sale")
+	flag.IntVar(&cfg.Users, "users", 100, "number of virtual users")
+	flag.IntVar(&cfg.SeatsPerUser, "seats-per-user", 2, "seats each user tries to book")
+	flag.StringVar(&cfg.Strategy, "strategy", strategyRandom, "seat selection: random, hot-seat or best-available")
+	flag.IntVar(&cfg.HotSeats, "hot-seats", 20, "size of the contested seat pool for the hot-seat strategy")
+	flag.DurationVar(&cfg.Think, "think", time.Second, "mean think time between steps (uniform 0.5x-1.5x)")
+	flag.Float64Var(&cfg.PayRate, "pay-rate", 0.8, "share of successful holds that complete payment")
+	flag.StringVar(&cfg.PayPath, "pay-path", "", "POST path that completes payment, {id} is the booking ID; empty records payment client-side only")
+	flag.IntVar(&cfg.Attempts, "attempts", 3, "booking attempts per user before giving up")
+	flag.DurationVar(&cfg.Ramp, "ramp", 0, "spread user arrivals over this duration")
+	flag.DurationVar(&cfg.Duration, "duration", 5*time.Minute, "stop the run after this long")
+	flag.DurationVar(&cfg.Interval, "interval", time.Second, "sell-through sampling interval")
+	flag.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.StringVar(&cfg.Output, "output", "table", "report format: table or json")
+	flag.Int64Var(&cfg.Seed, "seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	return cfg
+}
+
+func (c config) validate() error {
+	var problems []string
+
+	if c.Users < 1 {
+		problems = append(problems, "-users must be at least 1")
+	}
+
+	if c.SeatsPerUser < 1 {
+		problems = append(problems, "-seats-per-user must be at least 1")
+	}
+
+	if _, ok := strategies[c.Strategy]; !ok {
+		problems = append(problems, fmt.Sprintf("unknown -strategy %q", c.Strategy))
+	}
+
+	if c.HotSeats < c.SeatsPerUser {
+		problems = append(problems, "-hot-seats must be at least -seats-per-user")
+	}
+
+	if c.PayRate < 0 || c.PayRate > 1 {
+		problems = append(problems, "-pay-rate must be between 0 and 1")
+	}
+
+	if c.Attempts < 1 {
+		problems = append(problems, "-attempts must be at least 1")
+	}
+
+	if c.Interval <= 0 {
+		problems = append(problems, "-interval must be positive")
+	}
+
+	if c.Output != "table" && c.Output != "json" {
+		problems = append(problems, fmt.Sprintf("unknown -output %q", c.Output))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid flags: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func main() {
+	cfg := parseFlags()
+	if err := cfg.validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Duration)
+	defer cancel()
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client := &apiClient{
+		base: strings.TrimRight(cfg.Addr, "/"),
+		http: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        cfg.Users,
+				MaxIdleConnsPerHost: cfg.Users,
+			},
+		},
+	}
+
+	initial, err := client.availableSeats(ctx, cfg.EventID)
+	if err != nil {
+		log.Fatalf("Failed to load seats for event %s: %v", cfg.EventID, err)
+	}
+
+	if len(initial) == 0 {
+		log.Fatalf("Event %s has no available seats", cfg.EventID)
+	}
+
+	if cfg.PayPath == "" {
+		log.Println("No -pay-path given: payments are recorded client-side and unpaid holds expire on the server.")
+	}
+
+	log.Printf("On-sale for event %s: %d seats, %d users, strategy %s", cfg.EventID, len(initial), cfg.Users, cfg.Strategy)
+
+	stats := newStats(len(initial))
+	started := time.Now()
+
+	samplerDone := make(chan struct{})
+	go func() {
+		defer close(samplerDone)
+		stats.sample(ctx, started, cfg.Interval)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Users; i++ {
+		var delay time.Duration
+		if cfg.Ramp > 0 {
+			delay = time.Duration(int64(cfg.Ramp) * int64(i) / int64(cfg.Users))
+		}
+
+		vu := &virtualUser{
+			cfg:    cfg,
+			client: client,
+			stats:  stats,
+			rng:    rand.New(rand.NewSource(cfg.Seed + int64(i))),
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if !sleep(ctx, delay) {
+				return
+			}
+
+			vu.run(ctx)
+		}()
+	}
+
+	wg.Wait()
+	stop()
+	cancel()
+	<-samplerDone
+
+	report := stats.report(cfg, time.Since(started))
+
+	if cfg.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+
+		return
+	}
+
+	report.writeTable(os.Stdout)
+}
+
+// sleep waits for d or until ctx is done and reports whether the full
+// duration elapsed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
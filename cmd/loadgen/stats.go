@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	opSeats = "GET /v1/events/{eventID}/seats"
+	opBook  = "POST /v1/events/{eventID}/bookings"
+	opPay   = "POST payment"
+)
+
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeConflict
+	outcomeError
+)
+
+func outcomeOf(err error) outcome {
+	var status *statusError
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case errors.As(err, &status) && status.code == http.StatusConflict:
+		return outcomeConflict
+	default:
+		return outcomeError
+	}
+}
+
+type opStats struct {
+	latencies []time.Duration
+	success   int
+	conflict  int
+	errors    int
+}
+
+type stats struct {
+	mu sync.Mutex
+
+	totalSeats int
+	ops        map[string]*opStats
+
+	seatsHeld      int
+	seatsPaid      int
+	seatsAbandoned int
+	usersServed    int
+	usersGaveUp    int
+	usersSoldOut   int
+
+	timeline []sample
+}
+
+func newStats(totalSeats int) *stats {
+	return &stats{totalSeats: totalSeats, ops: make(map[string]*opStats)}
+}
+
+func (s *stats) observe(op string, latency time.Duration, result outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.ops[op]
+	if !ok {
+		o = &opStats{}
+		s.ops[op] = o
+	}
+
+	o.latencies = append(o.latencies, latency)
+
+	switch result {
+	case outcomeSuccess:
+		o.success++
+	case outcomeConflict:
+		o.conflict++
+	default:
+		o.errors++
+	}
+}
+
+func (s *stats) held(seats int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seatsHeld += seats
+	s.usersServed++
+}
+
+func (s *stats) paid(seats int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seatsHeld -= seats
+	s.seatsPaid += seats
+}
+
+func (s *stats) abandoned(seats int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seatsHeld -= seats
+	s.seatsAbandoned += seats
+}
+
+func (s *stats) gaveUp() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.usersGaveUp++
+}
+
+func (s *stats) soldOutUser() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.usersSoldOut++
+}
+
+// sample records sell-through every interval until ctx is done, plus once
+// more at the end.
+func (s *stats) sample(ctx context.Context, started time.Time, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.record(time.Since(started))
+			return
+		case <-ticker.C:
+			s.record(time.Since(started))
+		}
+	}
+}
+
+func (s *stats) record(elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.timeline = append(s.timeline, sample{
+		Elapsed:     elapsed.Round(time.Millisecond).String(),
+		SeatsHeld:   s.seatsHeld,
+		SeatsPaid:   s.seatsPaid,
+		SellThrough: percent(s.seatsPaid, s.totalSeats),
+	})
+}
+
+type sample struct {
+	Elapsed     string  `json:"elapsed"`
+	SeatsHeld   int     `json:"seats_held"`
+	SeatsPaid   int     `json:"seats_paid"`
+	SellThrough float64 `json:"sell_through_pct"`
+}
+
+type opReport struct {
+	Operation string  `json:"operation"`
+	Requests  int     `json:"requests"`
+	Success   int     `json:"success"`
+	Conflict  int     `json:"conflict"`
+	Errors    int     `json:"errors"`
+	P50Ms     float64 `json:"p50_ms"`
+	P90Ms     float64 `json:"p90_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+type report struct {
+	EventID        string     `json:"event_id"`
+	Strategy       string     `json:"strategy"`
+	Users          int        `json:"users"`
+	Duration       string     `json:"duration"`
+	TotalSeats     int        `json:"total_seats"`
+	SeatsPaid      int        `json:"seats_paid"`
+	SeatsHeld      int        `json:"seats_held"`
+	SeatsAbandoned int        `json:"seats_abandoned"`
+	SellThrough    float64    `json:"sell_through_pct"`
+	UsersServed    int        `json:"users_served"`
+	UsersGaveUp    int        `json:"users_gave_up"`
+	UsersSoldOut   int        `json:"users_sold_out"`
+	Operations     []opReport `json:"operations"`
+	Timeline       []sample   `json:"timeline"`
+}
+
+func (s *stats) report(cfg config, elapsed time.Duration) report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := report{
+		EventID:        cfg.EventID,
+		Strategy:       cfg.Strategy,
+		Users:          cfg.Users,
+		Duration:       elapsed.Round(time.Millisecond).String(),
+		TotalSeats:     s.totalSeats,
+		SeatsPaid:      s.seatsPaid,
+		SeatsHeld:      s.seatsHeld,
+		SeatsAbandoned: s.seatsAbandoned,
+		SellThrough:    percent(s.seatsPaid, s.totalSeats),
+		UsersServed:    s.usersServed,
+		UsersGaveUp:    s.usersGaveUp,
+		UsersSoldOut:   s.usersSoldOut,
+		Timeline:       s.timeline,
+	}
+
+	for _, op := range []string{opSeats, opBook, opPay} {
+		o, ok := s.ops[op]
+		if !ok {
+			continue
+		}
+
+		sort.Slice(o.latencies, func(i, j int) bool { return o.latencies[i] < o.latencies[j] })
+
+		r.Operations = append(r.Operations, opReport{
+			Operation: op,
+			Requests:  len(o.latencies),
+			Success:   o.success,
+			Conflict:  o.conflict,
+			Errors:    o.errors,
+			P50Ms:     millis(percentile(o.latencies, 50)),
+			P90Ms:     millis(percentile(o.latencies, 90)),
+			P95Ms:     millis(percentile(o.latencies, 95)),
+			P99Ms:     millis(percentile(o.latencies, 99)),
+			MaxMs:     millis(percentile(o.latencies, 100)),
+		})
+	}
+
+	return r
+}
+
+func (r report) writeTable(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Event %s, strategy %s, %d users, %s\n\n", r.EventID, r.Strategy, r.Users, r.Duration)
+
+	fmt.Fprintln(w, "OPERATION\tREQUESTS\tOK\tCONFLICT\tERROR\tP50 ms\tP90 ms\tP95 ms\tP99 ms\tMAX ms")
+	for _, op := range r.Operations {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\n",
+			op.Operation, op.Requests, op.Success, op.Conflict, op.Errors, op.P50Ms, op.P90Ms, op.P95Ms, op.P99Ms, op.MaxMs)
+	}
+
+	fmt.Fprintf(w, "\nSeats: %d total, %d paid, %d held, %d abandoned, %.1f%% sold\n",
+		r.TotalSeats, r.SeatsPaid, r.SeatsHeld, r.SeatsAbandoned, r.SellThrough)
+	fmt.Fprintf(w, "Users: %d got seats, %d gave up, %d found it sold out\n\n", r.UsersServed, r.UsersGaveUp, r.UsersSoldOut)
+
+	fmt.Fprintln(w, "ELAPSED\tHELD\tPAID\tSOLD %")
+	for _, s := range r.Timeline {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\n", s.Elapsed, s.SeatsHeld, s.SeatsPaid, s.SellThrough)
+	}
+
+	w.Flush()
+}
+
+// percentile uses the nearest-rank method on sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(part) / float64(total)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+const (
+	strategyRandom        = "random"
+	strategyHotSeat       = "hot-seat"
+	strategyBestAvailable = "best-available"
+)
+
+// A strategy picks up to n seats from the currently available seats, which
+// are sorted by section, row and seat number.
+type strategy func(rng *rand.Rand, available []domain.Seat, n, hotSeats int) []domain.Seat
+
+var strategies = map[string]strategy{
+	strategyRandom:        pickRandom,
+	strategyHotSeat:       pickHotSeat,
+	strategyBestAvailable: pickBestAvailable,
+}
+
+// pickRandom spreads users over the whole venue.
+func pickRandom(rng *rand.Rand, available []domain.Seat, n, _ int) []domain.Seat {
+	picked := make([]domain.Seat, 0, n)
+	for _, i := range rng.Perm(len(available)) {
+		if len(picked) == n {
+			break
+		}
+
+		picked = append(picked, available[i])
+	}
+
+	return picked
+}
+
+// pickHotSeat makes every user fight over the same few front seats.
+func pickHotSeat(rng *rand.Rand, available []domain.Seat, n, hotSeats int) []domain.Seat {
+	if len(available) > hotSeats {
+		available = available[:hotSeats]
+	}
+
+	return pickRandom(rng, available, n, hotSeats)
+}
+
+// pickBestAvailable takes the first n adjacent seats in one row, falling back
+// to the first n seats when no row has enough left.
+func pickBestAvailable(_ *rand.Rand, available []domain.Seat, n, _ int) []domain.Seat {
+	for start := 0; start+n <= len(available); start++ {
+		first := available[start]
+		last := available[start+n-1]
+		if first.Section == last.Section && first.RowNumber == last.RowNumber {
+			return available[start : start+n]
+		}
+	}
+
+	if len(available) > n {
+		available = available[:n]
+	}
+
+	return available
+}
+
+type virtualUser struct {
+	cfg    config
+	client *apiClient
+	stats  *stats
+	rng    *rand.Rand
+}
+
+// run plays one customer: look at the seats, think, try to book, and repeat
+// on conflict until a hold succeeds or the attempts run out.
+func (u *virtualUser) run(ctx context.Context) {
+	userID := uuid.NewString()
+
+	for attempt := 0; attempt < u.cfg.Attempts; attempt++ {
+		start := time.Now()
+		available, err := u.client.availableSeats(ctx, u.cfg.EventID)
+		u.stats.observe(opSeats, time.Since(start), outcomeOf(err))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		if len(available) == 0 {
+			u.stats.soldOutUser()
+			return
+		}
+
+		domain.SortSeats(available)
+		picked := strategies[u.cfg.Strategy](u.rng, available, u.cfg.SeatsPerUser, u.cfg.HotSeats)
+
+		if !sleep(ctx, u.think()) {
+			return
+		}
+
+		start = time.Now()
+		bookingID, err := u.client.book(ctx, userID, u.cfg.EventID, picked)
+		outcome := outcomeOf(err)
+		u.stats.observe(opBook, time.Since(start), outcome)
+
+		if outcome == outcomeConflict {
+			continue
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		u.stats.held(len(picked))
+		u.pay(ctx, userID, bookingID, len(picked))
+		return
+	}
+
+	u.stats.gaveUp()
+}
+
+func (u *virtualUser) pay(ctx context.Context, userID, bookingID string, seats int) {
+	if !sleep(ctx, u.think()) {
+		return
+	}
+
+	if u.rng.Float64() >= u.cfg.PayRate {
+		u.stats.abandoned(seats)
+		return
+	}
+
+	if u.cfg.PayPath != "" {
+		start := time.Now()
+		err := u.client.pay(ctx, userID, strings.ReplaceAll(u.cfg.PayPath, "{id}", bookingID))
+		u.stats.observe(opPay, time.Since(start), outcomeOf(err))
+		if err != nil {
+			return
+		}
+	}
+
+	u.stats.paid(seats)
+}
+
+func (u *virtualUser) think() time.Duration {
+	if u.cfg.Think <= 0 {
+		return 0
+	}
+
+	return time.Duration(float64(u.cfg.Think) * (0.5 + u.rng.Float64()))
+}
+
+// statusError is a non-2xx response.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.code, strings.TrimSpace(e.body))
+}
+
+type apiClient struct {
+	base string
+	http *http.Client
+}
+
+func (c *apiClient) availableSeats(ctx context.Context, eventID string) ([]domain.Seat, error) {
+	var listed []services.SeatResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/events/"+url.PathEscape(eventID)+"/seats", "", nil, &listed); err != nil {
+		return nil, err
+	}
+
+	seats := make([]domain.Seat, 0, len(listed))
+	for _, seat := range listed {
+		id, err := uuid.Parse(seat.SeatID)
+		if err != nil {
+			return nil, fmt.Errorf("seat id %q: %w", seat.SeatID, err)
+		}
+
+		seats = append(seats, domain.Seat{
+			ID:         id,
+			Section:    seat.Section,
+			RowNumber:  seat.RowNumber,
+			SeatNumber: seat.SeatNumber,
+		})
+	}
+
+	return seats, nil
+}
+
+func (c *apiClient) book(ctx context.Context, userID, eventID string, seats []domain.Seat) (string, error) {
+	var req struct {
+		SeatIDs []string `json:"seat_ids"`
+	}
+	for _, seat := range seats {
+		req.SeatIDs = append(req.SeatIDs, seat.ID.String())
+	}
+
+	var resp services.CreateBookingResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/events/"+url.PathEscape(eventID)+"/bookings", userID, req, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.BookingID, nil
+}
+
+func (c *apiClient) pay(ctx context.Context, userID, path string) error {
+	return c.do(ctx, http.MethodPost, path, userID, struct{}{}, nil)
+}
+
+func (c *apiClient) do(ctx context.Context, method, path, userID string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, reader)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{code: resp.StatusCode, body: string(msg)}
+	}
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	// /v1 wraps every body as {"data": ...}.
+	return json.NewDecoder(resp.Body).Decode(&struct{ Data any }{Data: out})
+}
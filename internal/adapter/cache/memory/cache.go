@@ -0,0 +1,71 @@
+// Package memory provides in-process caches for local development and tests.
+// Nothing is shared between replicas.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, ports.ErrCacheMiss
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, ports.ErrCacheMiss
+	}
+
+	value := make([]byte, len(entry.value))
+	copy(value, entry.value)
+
+	return value, nil
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := cacheEntry{value: make([]byte, len(value))}
+	copy(entry.value, value)
+
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+
+	return nil
+}
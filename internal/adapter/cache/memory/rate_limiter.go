@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+// sweepEvery is how many Allow calls pass between sweeps of keys that are
+// back to their full allowance.
+const sweepEvery = 1024
+
+// RateLimiter is the in-process counterpart of the Redis GCRA limiter. Each
+// replica enforces its own limit.
+type RateLimiter struct {
+	mu    sync.Mutex
+	tats  map[string]time.Time
+	calls int
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{tats: make(map[string]time.Time)}
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit ports.RateLimit) (ports.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	interval := limit.Window / time.Duration(limit.Limit)
+
+	tat, ok := l.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	next := tat.Add(interval)
+	if allowAt := next.Add(-limit.Window); allowAt.After(now) {
+		return ports.RateLimitResult{RetryAfter: allowAt.Sub(now), Reset: tat.Sub(now)}, nil
+	}
+
+	l.tats[key] = next
+
+	return ports.RateLimitResult{
+		Allowed:   true,
+		Remaining: int((limit.Window - next.Sub(now)) / interval),
+		Reset:     next.Sub(now),
+	}, nil
+}
+
+func (l *RateLimiter) sweep(now time.Time) {
+	l.calls++
+	if l.calls%sweepEvery != 0 {
+		return
+	}
+
+	for key, tat := range l.tats {
+		if !tat.After(now) {
+			delete(l.tats, key)
+		}
+	}
+}
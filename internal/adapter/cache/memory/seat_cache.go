@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+const seatRebuildLockTTL = 5 * time.Second
+
+type SeatCache struct {
+	mu          sync.Mutex
+	events      map[uuid.UUID]map[uuid.UUID]domain.Seat
+	generations map[uuid.UUID]int64
+	rebuildLock map[uuid.UUID]time.Time
+}
+
+func NewSeatCache() *SeatCache {
+	return &SeatCache{
+		events:      make(map[uuid.UUID]map[uuid.UUID]domain.Seat),
+		generations: make(map[uuid.UUID]int64),
+		rebuildLock: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (c *SeatCache) AvailableSeats(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.events[eventID]
+	if !ok {
+		return nil, false
+	}
+
+	seats := make([]domain.Seat, 0, len(cached))
+	for _, seat := range cached {
+		seats = append(seats, seat)
+	}
+
+	domain.SortSeats(seats)
+	return seats, true
+}
+
+func (c *SeatCache) SeatGeneration(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[eventID], nil
+}
+
+func (c *SeatCache) ReplaceAvailableSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat, generation int64) (bool, error) {
+	cached := make(map[uuid.UUID]domain.Seat, len(seats))
+	for _, seat := range seats {
+		cached[seat.ID] = seat
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[eventID] != generation {
+		return false, nil
+	}
+
+	c.events[eventID] = cached
+
+	return true, nil
+}
+
+func (c *SeatCache) RemoveSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[eventID]++
+
+	cached, ok := c.events[eventID]
+	if !ok {
+		return nil
+	}
+
+	for _, id := range seatIDs {
+		delete(cached, id)
+	}
+
+	return nil
+}
+
+// PutSeats only updates an event that has been loaded, matching the Redis
+// adapter.
+func (c *SeatCache) PutSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[eventID]++
+
+	cached, ok := c.events[eventID]
+	if !ok {
+		return nil
+	}
+
+	for _, seat := range seats {
+		cached[seat.ID] = seat
+	}
+
+	return nil
+}
+
+func (c *SeatCache) LockRebuild(ctx context.Context, eventID uuid.UUID) (bool, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if until, held := c.rebuildLock[eventID]; held && time.Now().Before(until) {
+		return false, func() {}
+	}
+
+	until := time.Now().Add(seatRebuildLockTTL)
+	c.rebuildLock[eventID] = until
+
+	return true, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.rebuildLock[eventID] == until {
+			delete(c.rebuildLock, eventID)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package noop provides caches that never store anything. They are used when
+// Redis is unavailable so every read goes straight to the database.
+package noop
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+type Cache struct{}
+
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, ports.ErrCacheMiss
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+type SeatCache struct{}
+
+func NewSeatCache() *SeatCache {
+	return &SeatCache{}
+}
+
+func (c *SeatCache) AvailableSeats(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, bool) {
+	return nil, false
+}
+
+func (c *SeatCache) SeatGeneration(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (c *SeatCache) ReplaceAvailableSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat, generation int64) (bool, error) {
+	return true, nil
+}
+
+func (c *SeatCache) RemoveSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) error {
+	return nil
+}
+
+func (c *SeatCache) PutSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat) error {
+	return nil
+}
+
+// LockRebuild always grants the lock; there is nobody to coordinate with.
+func (c *SeatCache) LockRebuild(ctx context.Context, eventID uuid.UUID) (bool, func()) {
+	return true, func() {}
+}
+
+// RateLimiter allows every request, so rate limiting is off when there is no
+// cache to count in.
+type RateLimiter struct{}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{}
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit ports.RateLimit) (ports.RateLimitResult, error) {
+	return ports.RateLimitResult{Allowed: true, Remaining: limit.Limit}, nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+type Cache struct {
+	client *goredis.Client
+}
+
+func NewCache(client *goredis.Client) *Cache {
+	return &Cache{client: client}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err == goredis.Nil {
+		return nil, ports.ErrCacheMiss
+	}
+
+	return data, err
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+// rateLimitScript is GCRA, a token bucket that stores only the theoretical
+// arrival time (TAT) of the next request. The clock is Redis's own, so
+// replicas with skewed clocks still share one limit.
+//
+// KEYS[1] = limit key
+// ARGV[1] = limit, ARGV[2] = window (ms)
+//
+// Returns {allowed, remaining, retry_after_ms, reset_ms}.
+var rateLimitScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local interval = window / limit
+
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+	tat = now
+end
+
+local next_tat = tat + interval
+local allow_at = next_tat - window
+if allow_at > now then
+	return {0, 0, math.ceil(allow_at - now), math.ceil(tat - now)}
+end
+
+redis.call('SET', KEYS[1], string.format('%.3f', next_tat), 'PX', math.ceil(next_tat - now))
+
+return {1, math.floor((window - (next_tat - now)) / interval), 0, math.ceil(next_tat - now)}
+`)
+
+// RateLimiter keeps one GCRA key per limited subject
+// (ratelimit:{key}, value = TAT in unix ms), expiring once it is back to its
+// full allowance.
+type RateLimiter struct {
+	client *goredis.Client
+}
+
+func NewRateLimiter(client *goredis.Client) *RateLimiter {
+	return &RateLimiter{client: client}
+}
+
+func rateLimitKey(key string) string {
+	return "ratelimit:" + key
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit ports.RateLimit) (ports.RateLimitResult, error) {
+	result, err := rateLimitScript.Run(ctx, l.client, []string{rateLimitKey(key)}, limit.Limit, limit.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return ports.RateLimitResult{}, err
+	}
+
+	if len(result) != 4 {
+		return ports.RateLimitResult{}, fmt.Errorf("unexpected rate limit script result %v", result)
+	}
+
+	return ports.RateLimitResult{
+		Allowed:    result[0] == 1,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+		Reset:      time.Duration(result[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	rediscache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/redis"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Denied(t *testing.T) {
+	db, mockRedis := redismock.NewClientMock()
+	limiter := rediscache.NewRateLimiter(db)
+
+	mockRedis.Regexp().ExpectEvalSha(".*", []string{"ratelimit:booking:user:u1"}, 5, int64(60000)).
+		SetVal([]interface{}{int64(0), int64(0), int64(11500), int64(59000)})
+
+	result, err := limiter.Allow(context.Background(), "booking:user:u1", ports.RateLimit{Limit: 5, Window: time.Minute})
+	require.NoError(t, err)
+
+	assert.Equal(t, ports.RateLimitResult{
+		Allowed:    false,
+		Remaining:  0,
+		RetryAfter: 11500 * time.Millisecond,
+		Reset:      59 * time.Second,
+	}, result)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
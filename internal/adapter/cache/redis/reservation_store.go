@@ -0,0 +1,197 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+// reserveScript claims every requested seat or none of them. A seat can be
+// claimed when it is still in the available-seat hash and has no live hold.
+// Claimed seats are removed from the available hash in the same step, so seat
+// reads see the reservation immediately.
+//
+// KEYS[1] = available seat hash, KEYS[2] = hold hash, KEYS[3] = seat generation
+// ARGV[1] = hold value, ARGV[2] = now (unix ms), ARGV[3] = generation TTL
+// seconds, ARGV[4..] = seat IDs
+//
+// Returns -1 when the seat hash is not loaded, otherwise the conflicting seats.
+var reserveScript = goredis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], '_loaded') == 0 then
+	return -1
+end
+
+local now = tonumber(ARGV[2])
+local conflicts = {}
+
+for i = 4, #ARGV do
+	local seat = ARGV[i]
+	local held = false
+	local hold = redis.call('HGET', KEYS[2], seat)
+	if hold then
+		local expires = tonumber(string.match(hold, '([^|]+)$'))
+		held = expires ~= nil and expires > now
+	end
+
+	if held or redis.call('HEXISTS', KEYS[1], seat) == 0 then
+		table.insert(conflicts, seat)
+	end
+end
+
+if #conflicts > 0 then
+	return conflicts
+end
+
+for i = 4, #ARGV do
+	redis.call('HSET', KEYS[2], ARGV[i], ARGV[1])
+	redis.call('HDEL', KEYS[1], ARGV[i])
+end
+redis.call('INCR', KEYS[3])
+redis.call('EXPIRE', KEYS[3], ARGV[3])
+
+return {}
+`)
+
+// releaseHoldScript removes holds, but only those still owned by the booking.
+//
+// KEYS[1] = hold hash, ARGV[1] = booking ID, ARGV[2..] = seat IDs
+var releaseHoldScript = goredis.NewScript(`
+local released = 0
+local prefix = ARGV[1] .. '|'
+
+for i = 2, #ARGV do
+	local hold = redis.call('HGET', KEYS[1], ARGV[i])
+	if hold and string.sub(hold, 1, #prefix) == prefix then
+		redis.call('HDEL', KEYS[1], ARGV[i])
+		released = released + 1
+	end
+end
+
+return released
+`)
+
+// ReservationStore keeps seat holds in a per-event hash
+// (holds:{event_id}, field = seat ID, value = "booking|created_ms|expires_ms")
+// next to the available-seat hash maintained by SeatCache.
+type ReservationStore struct {
+	client *goredis.Client
+}
+
+func NewReservationStore(client *goredis.Client) *ReservationStore {
+	return &ReservationStore{client: client}
+}
+
+func holdsKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("holds:{%s}", eventID)
+}
+
+func (s *ReservationStore) Reserve(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID, ttl time.Duration) ([]uuid.UUID, error) {
+	now := time.Now()
+	hold := fmt.Sprintf("%s|%d|%d", bookingID, now.UnixMilli(), now.Add(ttl).UnixMilli())
+
+	args := make([]interface{}, 0, len(seatIDs)+3)
+	args = append(args, hold, now.UnixMilli(), int(seatCacheTTL.Seconds()))
+	for _, id := range seatIDs {
+		args = append(args, id.String())
+	}
+
+	result, err := reserveScript.Run(ctx, s.client, []string{seatCacheKey(eventID), holdsKey(eventID), seatGenerationKey(eventID)}, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if code, ok := result.(int64); ok && code == -1 {
+		return nil, ports.ErrReservationNotReady
+	}
+
+	raw, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected reserve script result %T", result)
+	}
+
+	var conflicts []uuid.UUID
+	for _, value := range raw {
+		id, err := uuid.Parse(fmt.Sprint(value))
+		if err != nil {
+			return nil, err
+		}
+
+		conflicts = append(conflicts, id)
+	}
+
+	return conflicts, nil
+}
+
+func (s *ReservationStore) Release(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID) error {
+	if len(seatIDs) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(seatIDs)+1)
+	args = append(args, bookingID.String())
+	for _, id := range seatIDs {
+		args = append(args, id.String())
+	}
+
+	return releaseHoldScript.Run(ctx, s.client, []string{holdsKey(eventID)}, args...).Err()
+}
+
+func (s *ReservationStore) Holds(ctx context.Context, eventID uuid.UUID) ([]domain.SeatHold, error) {
+	fields, err := s.client.HGetAll(ctx, holdsKey(eventID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	holds := make([]domain.SeatHold, 0, len(fields))
+	for field, value := range fields {
+		hold, err := parseHold(field, value)
+		if err != nil {
+			return nil, err
+		}
+
+		holds = append(holds, hold)
+	}
+
+	return holds, nil
+}
+
+func parseHold(field string, value string) (domain.SeatHold, error) {
+	seatID, err := uuid.Parse(field)
+	if err != nil {
+		return domain.SeatHold{}, fmt.Errorf("invalid hold seat %q: %w", field, err)
+	}
+
+	parts := strings.Split(value, "|")
+	if len(parts) != 3 {
+		return domain.SeatHold{}, fmt.Errorf("invalid hold value %q", value)
+	}
+
+	bookingID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return domain.SeatHold{}, fmt.Errorf("invalid hold booking %q: %w", parts[0], err)
+	}
+
+	createdMs, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return domain.SeatHold{}, fmt.Errorf("invalid hold value %q", value)
+	}
+
+	expiresMs, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return domain.SeatHold{}, fmt.Errorf("invalid hold value %q", value)
+	}
+
+	return domain.SeatHold{
+		SeatID:    seatID,
+		BookingID: bookingID,
+		CreatedAt: time.UnixMilli(createdMs),
+		ExpiresAt: time.UnixMilli(expiresMs),
+	}, nil
+}
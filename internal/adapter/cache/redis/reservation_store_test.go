@@ -0,0 +1,30 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	rediscache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationStore_HoldsShareTheSeatHashSlot(t *testing.T) {
+	db, mockRedis := redismock.NewClientMock()
+	store := rediscache.NewReservationStore(db)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	mockRedis.ExpectHGetAll("holds:{" + eventID.String() + "}").SetVal(map[string]string{})
+
+	holds, err := store.Holds(ctx, eventID)
+
+	assert.NoError(t, err)
+	assert.Empty(t, holds)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
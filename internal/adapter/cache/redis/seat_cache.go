@@ -0,0 +1,218 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+const (
+	// seatCacheLoadedField marks a hash as fully built. Without it an empty
+	// hash (sold-out event) would look the same as a missing one.
+	seatCacheLoadedField = "_loaded"
+	seatCacheTTL         = 1 * time.Hour
+	seatRebuildLockTTL   = 5 * time.Second
+)
+
+// putIfLoadedScript bumps the generation and only writes into a hash that is
+// already fully built, so a release racing a rebuild can't leave behind a
+// partial hash.
+//
+// KEYS[1] = seat hash, KEYS[2] = generation
+// ARGV[1] = loaded field, ARGV[2] = TTL seconds, ARGV[3..] = field/value pairs
+var putIfLoadedScript = goredis.NewScript(`
+redis.call('INCR', KEYS[2])
+redis.call('EXPIRE', KEYS[2], ARGV[2])
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+return redis.call('HSET', KEYS[1], unpack(ARGV, 3))
+`)
+
+// replaceScript rebuilds the hash, unless the generation moved since the
+// caller read it: the seats it loaded are then older than the hash.
+//
+// KEYS[1] = seat hash, KEYS[2] = generation
+// ARGV[1] = expected generation, ARGV[2] = TTL seconds, ARGV[3..] = field/value pairs
+var replaceScript = goredis.NewScript(`
+if tonumber(redis.call('GET', KEYS[2]) or '0') ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+for i = 3, #ARGV, 2 do
+	redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+// releaseLockScript deletes a lock only if it still holds our token, so a
+// slow rebuild can't release a lock that already expired and was re-taken.
+var releaseLockScript = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// SeatCache keeps the available seats of an event in a Redis hash
+// (seats:{event_id}, field = seat ID, value = seat JSON). Seat state changes
+// update single fields in place, so reads don't have to rebuild the whole set
+// from Postgres after every booking. Each change also bumps a counter
+// (seats:{event_id}:gen) that rebuilds compare against.
+type SeatCache struct {
+	client *goredis.Client
+}
+
+func NewSeatCache(client *goredis.Client) *SeatCache {
+	return &SeatCache{client: client}
+}
+
+// An event's keys share the {event_id} hash tag, so the scripts that touch
+// several of them run on one Redis Cluster slot instead of failing with
+// CROSSSLOT.
+func seatCacheKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("seats:{%s}", eventID)
+}
+
+func seatGenerationKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("seats:{%s}:gen", eventID)
+}
+
+func seatRebuildLockKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("seats:{%s}:rebuild", eventID)
+}
+
+func (c *SeatCache) AvailableSeats(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, bool) {
+	fields, err := c.client.HGetAll(ctx, seatCacheKey(eventID)).Result()
+	if err != nil {
+		return nil, false
+	}
+
+	if _, loaded := fields[seatCacheLoadedField]; !loaded {
+		return nil, false
+	}
+
+	seats := make([]domain.Seat, 0, len(fields)-1)
+	for field, value := range fields {
+		if field == seatCacheLoadedField {
+			continue
+		}
+
+		var seat domain.Seat
+		if err := json.Unmarshal([]byte(value), &seat); err != nil {
+			return nil, false
+		}
+
+		seats = append(seats, seat)
+	}
+
+	domain.SortSeats(seats)
+	return seats, true
+}
+
+func (c *SeatCache) SeatGeneration(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	generation, err := c.client.Get(ctx, seatGenerationKey(eventID)).Int64()
+	if err == goredis.Nil {
+		return 0, nil
+	}
+
+	return generation, err
+}
+
+// ReplaceAvailableSeats rebuilds the whole hash atomically if the generation
+// still matches.
+func (c *SeatCache) ReplaceAvailableSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat, generation int64) (bool, error) {
+	args := make([]interface{}, 0, 2*len(seats)+4)
+	args = append(args, generation, int(seatCacheTTL.Seconds()), seatCacheLoadedField, "1")
+	for _, seat := range seats {
+		data, err := json.Marshal(seat)
+		if err != nil {
+			return false, err
+		}
+
+		args = append(args, seat.ID.String(), string(data))
+	}
+
+	keys := []string{seatCacheKey(eventID), seatGenerationKey(eventID)}
+
+	replaced, err := replaceScript.Run(ctx, c.client, keys, args...).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return replaced == 1, nil
+}
+
+func (c *SeatCache) RemoveSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) error {
+	if len(seatIDs) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(seatIDs))
+	for _, id := range seatIDs {
+		fields = append(fields, id.String())
+	}
+
+	_, err := c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HDel(ctx, seatCacheKey(eventID), fields...)
+		pipe.Incr(ctx, seatGenerationKey(eventID))
+		pipe.Expire(ctx, seatGenerationKey(eventID), seatCacheTTL)
+		return nil
+	})
+
+	return err
+}
+
+// PutSeats only updates a built hash; a missing one is left for the next
+// rebuild.
+func (c *SeatCache) PutSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat) error {
+	if len(seats) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, 2*len(seats)+2)
+	args = append(args, seatCacheLoadedField, int(seatCacheTTL.Seconds()))
+	for _, seat := range seats {
+		data, err := json.Marshal(seat)
+		if err != nil {
+			return err
+		}
+
+		args = append(args, seat.ID.String(), string(data))
+	}
+
+	keys := []string{seatCacheKey(eventID), seatGenerationKey(eventID)}
+
+	err := putIfLoadedScript.Run(ctx, c.client, keys, args...).Err()
+	if err == goredis.Nil {
+		return nil
+	}
+
+	return err
+}
+
+// LockRebuild tries to become the replica that rebuilds the hash. If Redis is
+// unreachable the caller is treated as the owner so reads keep working.
+func (c *SeatCache) LockRebuild(ctx context.Context, eventID uuid.UUID) (bool, func()) {
+	key := seatRebuildLockKey(eventID)
+	token := uuid.NewString()
+
+	ok, err := c.client.SetNX(ctx, key, token, seatRebuildLockTTL).Result()
+	if err != nil {
+		return true, func() {}
+	}
+
+	if !ok {
+		return false, func() {}
+	}
+
+	return true, func() {
+		releaseLockScript.Run(ctx, c.client, []string{key}, token)
+	}
+}
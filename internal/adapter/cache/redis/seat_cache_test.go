@@ -0,0 +1,76 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	rediscache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/redis"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeatCache_AvailableSeats(t *testing.T) {
+	db, mockRedis := redismock.NewClientMock()
+	cache := rediscache.NewSeatCache(db)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	cacheKey := "seats:{" + eventID.String() + "}"
+	seat := domain.Seat{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "1", Status: domain.SeatAvailable, Version: 3}
+	seatJSON, _ := json.Marshal(seat)
+
+	mockRedis.ExpectHGetAll(cacheKey).SetVal(map[string]string{
+		"_loaded":        "1",
+		seat.ID.String(): string(seatJSON),
+	})
+
+	seats, ok := cache.AvailableSeats(ctx, eventID)
+
+	assert.True(t, ok)
+	assert.Equal(t, []domain.Seat{seat}, seats)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSeatCache_SoldOutEventIsLoaded(t *testing.T) {
+	db, mockRedis := redismock.NewClientMock()
+	cache := rediscache.NewSeatCache(db)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	mockRedis.ExpectHGetAll("seats:{" + eventID.String() + "}").SetVal(map[string]string{"_loaded": "1"})
+
+	seats, ok := cache.AvailableSeats(ctx, eventID)
+
+	assert.True(t, ok)
+	assert.Empty(t, seats)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSeatCache_PartialHashIsNotLoaded(t *testing.T) {
+	db, mockRedis := redismock.NewClientMock()
+	cache := rediscache.NewSeatCache(db)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	mockRedis.ExpectHGetAll("seats:{" + eventID.String() + "}").SetVal(map[string]string{uuid.NewString(): "{}"})
+
+	seats, ok := cache.AvailableSeats(ctx, eventID)
+
+	assert.False(t, ok)
+	assert.Nil(t, seats)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys, the gRPC spelling of the X-User-ID and X-Request-ID headers.
+const (
+	UserIDKey    = "x-user-id"
+	RequestIDKey = "x-request-id"
+)
+
+// UnaryLogging and StreamLogging give every call an ID, reusing the caller's
+// x-request-id when it is usable, and echo it in the response header. The
+// call context gets a logger carrying request_id, and one line is logged per
+// call, when it ends.
+func UnaryLogging(logger *slog.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = withRequestID(ctx, logger)
+		gogrpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestIDFromContext(ctx)))
+
+		resp, err := handler(ctx, req)
+
+		logCall(ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+func StreamLogging(logger *slog.Logger) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestID(ss.Context(), logger)
+		ss.SetHeader(metadata.Pairs(RequestIDKey, requestIDFromContext(ctx)))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		logCall(ctx, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+type requestIDKey struct{}
+
+func withRequestID(ctx context.Context, logger *slog.Logger) context.Context {
+	requestID := logging.RequestID(firstValue(ctx, RequestIDKey))
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+
+	return logging.With(logging.WithLogger(ctx, logger), "request_id", requestID)
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	}
+
+	logging.FromContext(ctx).Log(ctx, level, "gRPC request",
+		"method", method,
+		"code", code.String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// UnaryRecover and StreamRecover turn a panic into INTERNAL, logging it with
+// its stack trace.
+func UnaryRecover(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicked(ctx, info.FullMethod, p)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func StreamRecover(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicked(ss.Context(), info.FullMethod, p)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func panicked(ctx context.Context, method string, p any) error {
+	logging.FromContext(ctx).ErrorContext(ctx, "gRPC handler panicked",
+		"method", method,
+		"panic", p,
+		"stack", string(debug.Stack()),
+	)
+
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// UnaryAuth and StreamAuth read the caller from x-user-id, answering
+// UNAUTHENTICATED when a method that acts for a user is called without it.
+// There is no real authentication yet, matching the HTTP API.
+func UnaryAuth(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func StreamAuth(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+type userKey struct{}
+
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	userID := firstValue(ctx, UserIDKey)
+	if userID == "" {
+		if userMethods[method] {
+			return nil, status.Error(codes.Unauthenticated, "missing "+UserIDKey+" metadata")
+		}
+
+		return ctx, nil
+	}
+
+	return context.WithValue(ctx, userKey{}, userID), nil
+}
+
+func userFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userKey{}).(string)
+	return userID
+}
+
+func firstValue(ctx context.Context, key string) string {
+	if values := valuesOf(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func valuesOf(ctx context.Context, key string) []string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return md.Get(key)
+}
+
+// serverStream replaces a stream's context, so values added by an
+// interceptor reach the handler.
+type serverStream struct {
+	gogrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
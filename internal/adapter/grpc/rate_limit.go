@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitPolicies are the HTTP API's booking and seat read limits. Calls
+// are counted under the same keys as HTTP requests, so a client can't get
+// around a limit by switching protocols. A policy with a zero Limit is off.
+type RateLimitPolicies struct {
+	BookingPerUserEvent ports.RateLimit
+	BookingPerEvent     ports.RateLimit
+	SeatReadsPerIP      ports.RateLimit
+	// ClientIPKey names a metadata entry set by a trusted proxy, such as
+	// x-forwarded-for, whose last entry is the client address. Empty uses the
+	// connection's peer address.
+	ClientIPKey string
+}
+
+type rateLimiter struct {
+	limiter  ports.RateLimiter
+	policies RateLimitPolicies
+}
+
+type rateCheck struct {
+	policy string
+	key    string
+	limit  ports.RateLimit
+}
+
+// SetRateLimiter limits CreateBooking and ListSeats calls. Without this call
+// nothing is limited.
+func (s *Server) SetRateLimiter(limiter ports.RateLimiter, policies RateLimitPolicies) {
+	s.limiter = &rateLimiter{limiter: limiter, policies: policies}
+}
+
+// allowBooking checks the booking policies. IDs that don't parse aren't
+// counted; the service rejects them anyway.
+func (l *rateLimiter) allowBooking(ctx context.Context, userID, eventID string) error {
+	if l == nil {
+		return nil
+	}
+
+	event, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil
+	}
+
+	var checks []rateCheck
+	if user, err := uuid.Parse(userID); err == nil {
+		checks = append(checks, rateCheck{
+			policy: "booking_per_user_event",
+			key:    fmt.Sprintf("booking:user:%s:event:%s", user, event),
+			limit:  l.policies.BookingPerUserEvent,
+		})
+	}
+
+	checks = append(checks, rateCheck{
+		policy: "booking_per_event",
+		key:    fmt.Sprintf("booking:event:%s", event),
+		limit:  l.policies.BookingPerEvent,
+	})
+
+	return l.allow(ctx, checks...)
+}
+
+func (l *rateLimiter) allowSeatRead(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	return l.allow(ctx, rateCheck{
+		policy: "seat_reads_per_ip",
+		key:    "seats:ip:" + l.clientIP(ctx),
+		limit:  l.policies.SeatReadsPerIP,
+	})
+}
+
+// allow counts the call against every check and returns RESOURCE_EXHAUSTED,
+// with a RetryInfo detail, when one is exhausted. If the limiter fails the
+// call is allowed, as over HTTP.
+func (l *rateLimiter) allow(ctx context.Context, checks ...rateCheck) error {
+	for _, check := range checks {
+		if check.limit.Limit <= 0 {
+			continue
+		}
+
+		result, err := l.limiter.Allow(ctx, check.key, check.limit)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "Rate limiter unavailable, allowing request", "policy", check.policy, "error", err)
+			continue
+		}
+
+		if !result.Allowed {
+			logging.FromContext(ctx).DebugContext(ctx, "Request rate limited", "policy", check.policy, "retry_after", result.RetryAfter.String())
+			return rateLimited(result.RetryAfter)
+		}
+	}
+
+	return nil
+}
+
+func rateLimited(retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return st.Err()
+}
+
+func (l *rateLimiter) clientIP(ctx context.Context) string {
+	if l.policies.ClientIPKey != "" {
+		if values := valuesOf(ctx, l.policies.ClientIPKey); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
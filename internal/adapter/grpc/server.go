@@ -0,0 +1,263 @@
+// Package grpc serves ticketv1.TicketService, the gRPC counterpart of the
+// HTTP handlers, on top of the same services.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	ticketv1 "github.com/srgjo27/scalable_ticket/api/ticket/v1"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// userMethods act on behalf of a user and need the x-user-id metadata entry.
+var userMethods = map[string]bool{
+	ticketv1.TicketService_CreateBooking_FullMethodName:  true,
+	ticketv1.TicketService_GetBooking_FullMethodName:     true,
+	ticketv1.TicketService_ListMyBookings_FullMethodName: true,
+}
+
+type Server struct {
+	ticketv1.UnimplementedTicketServiceServer
+
+	svc           *services.BookingService
+	watchInterval time.Duration
+	limiter       *rateLimiter
+
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+// NewServer returns a TicketService whose WatchSeats streams are fed by a
+// per-event poller that checks for seat changes every watchInterval.
+func NewServer(svc *services.BookingService, watchInterval time.Duration) *Server {
+	return &Server{
+		svc:           svc,
+		watchInterval: watchInterval,
+		draining:      make(chan struct{}),
+	}
+}
+
+// Drain ends every WatchSeats stream with UNAVAILABLE, so clients reconnect
+// to another replica and a graceful stop doesn't wait on streams that would
+// otherwise never finish.
+func (s *Server) Drain() {
+	s.drainOnce.Do(func() { close(s.draining) })
+}
+
+func (s *Server) ListSeats(ctx context.Context, req *ticketv1.ListSeatsRequest) (*ticketv1.ListSeatsResponse, error) {
+	if err := s.limiter.allowSeatRead(ctx); err != nil {
+		return nil, err
+	}
+
+	seats, err := s.svc.GetAvailableSeats(ctx, req.GetEventId())
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return &ticketv1.ListSeatsResponse{Seats: toSeats(seats)}, nil
+}
+
+func (s *Server) WatchSeats(req *ticketv1.WatchSeatsRequest, stream ticketv1.TicketService_WatchSeatsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-s.draining:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := s.svc.WatchSeats(ctx, req.GetEventId(), s.watchInterval, func(update services.SeatUpdate) error {
+		return stream.Send(&ticketv1.WatchSeatsResponse{Snapshot: update.Snapshot, Seats: toSeats(update.Seats)})
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return st.Err()
+		}
+
+		return queryError(err)
+	}
+
+	select {
+	case <-s.draining:
+		return status.Error(codes.Unavailable, "server shutting down")
+	default:
+		return status.FromContextError(stream.Context().Err()).Err()
+	}
+}
+
+func (s *Server) CreateBooking(ctx context.Context, req *ticketv1.CreateBookingRequest) (*ticketv1.CreateBookingResponse, error) {
+	userID := userFromContext(ctx)
+	if err := s.limiter.allowBooking(ctx, userID, req.GetEventId()); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.svc.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  userID,
+		EventID: req.GetEventId(),
+		SeatIDs: req.GetSeatIds(),
+	})
+	if err != nil {
+		errMsg := err.Error()
+
+		var invalid *services.ValidationError
+		if errors.As(err, &invalid) {
+			return nil, invalidArgument(invalid)
+		} else if errors.Is(err, services.ErrSeatUnavailable) || strings.Contains(errMsg, "seat not found") {
+			return nil, status.Error(codes.FailedPrecondition, errMsg)
+		} else if strings.Contains(errMsg, "invalid") {
+			return nil, status.Error(codes.InvalidArgument, errMsg)
+		} else if errors.Is(err, services.ErrBookingQueueFull) {
+			return nil, status.Error(codes.Unavailable, errMsg)
+		}
+
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	return &ticketv1.CreateBookingResponse{
+		BookingId:   resp.BookingID,
+		TotalAmount: resp.TotalAmount,
+		Status:      bookingStatus(resp.Status),
+		ExpiresAt:   timestamp(resp.ExpiresAt),
+	}, nil
+}
+
+func (s *Server) GetBooking(ctx context.Context, req *ticketv1.GetBookingRequest) (*ticketv1.GetBookingResponse, error) {
+	resp, err := s.svc.GetBooking(ctx, userFromContext(ctx), req.GetBookingId())
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return &ticketv1.GetBookingResponse{Booking: toBooking(*resp)}, nil
+}
+
+func (s *Server) ListMyBookings(ctx context.Context, req *ticketv1.ListMyBookingsRequest) (*ticketv1.ListMyBookingsResponse, error) {
+	var statusFilter string
+	if req.GetStatus() != ticketv1.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		// Unknown enum numbers come out as digits, which the service rejects.
+		statusFilter = strings.TrimPrefix(req.GetStatus().String(), "BOOKING_STATUS_")
+	}
+
+	resp, err := s.svc.ListUserBookings(ctx, services.ListBookingsRequest{
+		UserID:  userFromContext(ctx),
+		Status:  statusFilter,
+		EventID: req.GetEventId(),
+		Cursor:  req.GetCursor(),
+		Limit:   int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	bookings := make([]*ticketv1.Booking, 0, len(resp.Bookings))
+	for _, booking := range resp.Bookings {
+		bookings = append(bookings, toBooking(booking))
+	}
+
+	return &ticketv1.ListMyBookingsResponse{Bookings: bookings, NextCursor: resp.NextCursor}, nil
+}
+
+// queryError maps service errors the way the HTTP handlers' writeQueryError
+// does.
+func queryError(err error) error {
+	errMsg := err.Error()
+
+	if strings.Contains(errMsg, "not found") {
+		return status.Error(codes.NotFound, errMsg)
+	} else if strings.Contains(errMsg, "invalid") {
+		return status.Error(codes.InvalidArgument, errMsg)
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// invalidArgument reports each invalid field as a BadRequest field violation,
+// the gRPC counterpart of the "fields" list in HTTP error bodies.
+func invalidArgument(err *services.ValidationError) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(err.Fields))
+	for _, f := range err.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return st.Err()
+}
+
+func toSeats(seats []domain.Seat) []*ticketv1.Seat {
+	out := make([]*ticketv1.Seat, 0, len(seats))
+	for _, seat := range seats {
+		out = append(out, &ticketv1.Seat{
+			Id:         seat.ID.String(),
+			EventId:    seat.EventID.String(),
+			TierId:     seat.TierID.String(),
+			Section:    seat.Section,
+			RowNumber:  seat.RowNumber,
+			SeatNumber: seat.SeatNumber,
+			Status:     ticketv1.SeatStatus(ticketv1.SeatStatus_value["SEAT_STATUS_"+string(seat.Status)]),
+		})
+	}
+
+	return out
+}
+
+func toBooking(b services.BookingDetailResponse) *ticketv1.Booking {
+	items := make([]*ticketv1.BookingItem, 0, len(b.Items))
+	for _, item := range b.Items {
+		items = append(items, &ticketv1.BookingItem{
+			SeatId:     item.SeatID,
+			Section:    item.Section,
+			RowNumber:  item.RowNumber,
+			SeatNumber: item.SeatNumber,
+			Price:      item.Price,
+		})
+	}
+
+	booking := &ticketv1.Booking{
+		BookingId:            b.BookingID,
+		UserId:               b.UserID,
+		EventId:              b.EventID,
+		Status:               bookingStatus(b.Status),
+		TotalAmount:          b.TotalAmount,
+		PaymentStatus:        b.PaymentStatus,
+		CreatedAt:            timestamp(b.CreatedAt),
+		ExpiresAt:            timestamp(b.ExpiresAt),
+		HoldRemainingSeconds: b.HoldRemainingSeconds,
+		Items:                items,
+	}
+	if b.ConfirmedAt != nil {
+		booking.ConfirmedAt = timestamp(*b.ConfirmedAt)
+	}
+
+	return booking
+}
+
+// bookingStatus maps a status such as "PENDING" to its enum value, or to
+// BOOKING_STATUS_UNSPECIFIED when it is unknown.
+func bookingStatus(s string) ticketv1.BookingStatus {
+	return ticketv1.BookingStatus(ticketv1.BookingStatus_value["BOOKING_STATUS_"+s])
+}
+
+// timestamp parses the RFC 3339 times in service responses.
+func timestamp(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,248 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	ticketv1 "github.com/srgjo27/scalable_ticket/api/ticket/v1"
+	memorycache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/memory"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	grpcapi "github.com/srgjo27/scalable_ticket/internal/adapter/grpc"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// IDs from memory.SeedDemo.
+const (
+	demoEventID = "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+	demoSeatID  = "c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a01"
+	demoUserID  = "d1eebc99-9c0b-4ef8-bb6d-6bb9bd380a55"
+)
+
+// startServer serves TicketService over an in-memory listener, backed by the
+// memory demo data, and returns a client for it.
+func startServer(t *testing.T) (ticketv1.TicketServiceClient, *grpcapi.Server) {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	return serve(t, services.NewBookingService(memory.NewSeatRepository(store), memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache()))
+}
+
+func serve(t *testing.T, bookingService *services.BookingService) (ticketv1.TicketServiceClient, *grpcapi.Server) {
+	ticketService := grpcapi.NewServer(bookingService, 10*time.Millisecond)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(grpcapi.UnaryLogging(logger), grpcapi.UnaryRecover, grpcapi.UnaryAuth),
+		gogrpc.ChainStreamInterceptor(grpcapi.StreamLogging(logger), grpcapi.StreamRecover, grpcapi.StreamAuth),
+	)
+	ticketv1.RegisterTicketServiceServer(server, ticketService)
+
+	ln := bufconn.Listen(1 << 20)
+	go server.Serve(ln)
+	t.Cleanup(server.Stop)
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return ticketv1.NewTicketServiceClient(conn), ticketService
+}
+
+func asUser(ctx context.Context, userID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpcapi.UserIDKey, userID)
+}
+
+func TestServer_CreateAndGetBooking(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	seats, err := client.ListSeats(ctx, &ticketv1.ListSeatsRequest{EventId: demoEventID})
+	require.NoError(t, err)
+	require.NotEmpty(t, seats.GetSeats())
+	assert.Equal(t, ticketv1.SeatStatus_SEAT_STATUS_AVAILABLE, seats.GetSeats()[0].GetStatus())
+
+	req := &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}}
+
+	_, err = client.CreateBooking(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	created, err := client.CreateBooking(asUser(ctx, demoUserID), req)
+	require.NoError(t, err)
+	assert.Equal(t, ticketv1.BookingStatus_BOOKING_STATUS_PENDING, created.GetStatus())
+	assert.NotNil(t, created.GetExpiresAt())
+
+	_, err = client.CreateBooking(asUser(ctx, "e1eebc99-9c0b-4ef8-bb6d-6bb9bd380a66"), req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	got, err := client.GetBooking(asUser(ctx, demoUserID), &ticketv1.GetBookingRequest{BookingId: created.GetBookingId()})
+	require.NoError(t, err)
+	assert.Equal(t, demoUserID, got.GetBooking().GetUserId())
+	require.Len(t, got.GetBooking().GetItems(), 1)
+	assert.Equal(t, demoSeatID, got.GetBooking().GetItems()[0].GetSeatId())
+
+	_, err = client.GetBooking(asUser(ctx, "e1eebc99-9c0b-4ef8-bb6d-6bb9bd380a66"), &ticketv1.GetBookingRequest{BookingId: created.GetBookingId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err := client.ListMyBookings(asUser(ctx, demoUserID), &ticketv1.ListMyBookingsRequest{Status: ticketv1.BookingStatus_BOOKING_STATUS_PENDING})
+	require.NoError(t, err)
+	assert.Len(t, list.GetBookings(), 1)
+}
+
+// contendedSeatRepository lets a rival request lock every seat between the
+// caller reading it and locking it, so the caller loses the optimistic lock.
+type contendedSeatRepository struct {
+	*memory.SeatRepository
+}
+
+func (r contendedSeatRepository) LockSeat(ctx context.Context, seatID, bookingID, actorID uuid.UUID, currentVersion int) error {
+	if err := r.SeatRepository.LockSeat(ctx, seatID, uuid.New(), uuid.New(), currentVersion); err != nil {
+		return err
+	}
+
+	return r.SeatRepository.LockSeat(ctx, seatID, bookingID, actorID, currentVersion)
+}
+
+func TestServer_LostSeatLockIsFailedPrecondition(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	seatRepo := contendedSeatRepository{memory.NewSeatRepository(store)}
+	client, _ := serve(t, services.NewBookingService(seatRepo, memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache()))
+
+	_, err := client.CreateBooking(asUser(context.Background(), demoUserID), &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "seat 1 is not available", status.Convert(err).Message())
+}
+
+func TestServer_CreateBookingListsInvalidFields(t *testing.T) {
+	client, _ := startServer(t)
+
+	_, err := client.CreateBooking(asUser(context.Background(), demoUserID), &ticketv1.CreateBookingRequest{
+		EventId: demoEventID,
+		SeatIds: []string{"A1", demoSeatID},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "seat_ids[0]", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "must be a UUID", badRequest.GetFieldViolations()[0].GetDescription())
+}
+
+func TestServer_RateLimitsBookings(t *testing.T) {
+	client, ticketService := startServer(t)
+	ticketService.SetRateLimiter(memorycache.NewRateLimiter(), grpcapi.RateLimitPolicies{
+		BookingPerUserEvent: ports.RateLimit{Limit: 1, Window: time.Minute},
+	})
+	ctx := asUser(context.Background(), demoUserID)
+
+	_, err := client.CreateBooking(ctx, &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}})
+	require.NoError(t, err)
+
+	// Counted per attempt, so the second one is limited before the service
+	// sees the taken seat.
+	_, err = client.CreateBooking(ctx, &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	retry, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Greater(t, retry.GetRetryDelay().AsDuration(), time.Duration(0))
+
+	// Another user has a bucket of their own.
+	_, err = client.CreateBooking(asUser(context.Background(), uuid.NewString()), &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServer_RateLimitsSeatReads(t *testing.T) {
+	client, ticketService := startServer(t)
+	ticketService.SetRateLimiter(memorycache.NewRateLimiter(), grpcapi.RateLimitPolicies{
+		SeatReadsPerIP: ports.RateLimit{Limit: 2, Window: time.Minute},
+		ClientIPKey:    "x-forwarded-for",
+	})
+
+	from := func(ip string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-forwarded-for", ip)
+	}
+
+	for range 2 {
+		_, err := client.ListSeats(from("203.0.113.7"), &ticketv1.ListSeatsRequest{EventId: demoEventID})
+		require.NoError(t, err)
+	}
+
+	_, err := client.ListSeats(from("203.0.113.7"), &ticketv1.ListSeatsRequest{EventId: demoEventID})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = client.ListSeats(from("203.0.113.8"), &ticketv1.ListSeatsRequest{EventId: demoEventID})
+	assert.NoError(t, err)
+}
+
+func TestServer_ListSeatsRejectsBadEventID(t *testing.T) {
+	client, _ := startServer(t)
+
+	_, err := client.ListSeats(context.Background(), &ticketv1.ListSeatsRequest{EventId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_EchoesRequestID(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpcapi.RequestIDKey, "trace-me")
+
+	var header metadata.MD
+	_, err := client.ListSeats(ctx, &ticketv1.ListSeatsRequest{EventId: demoEventID}, gogrpc.Header(&header))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"trace-me"}, header.Get(grpcapi.RequestIDKey))
+}
+
+func TestServer_WatchSeatsStreamsChangesUntilDrained(t *testing.T) {
+	client, ticketService := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchSeats(ctx, &ticketv1.WatchSeatsRequest{EventId: demoEventID})
+	require.NoError(t, err)
+
+	snapshot, err := stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, snapshot.GetSnapshot())
+	assert.NotEmpty(t, snapshot.GetSeats())
+
+	_, err = client.CreateBooking(asUser(ctx, demoUserID), &ticketv1.CreateBookingRequest{EventId: demoEventID, SeatIds: []string{demoSeatID}})
+	require.NoError(t, err)
+
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.False(t, update.GetSnapshot())
+	require.Len(t, update.GetSeats(), 1)
+	assert.Equal(t, demoSeatID, update.GetSeats()[0].GetId())
+	assert.Equal(t, ticketv1.SeatStatus_SEAT_STATUS_LOCKED, update.GetSeats()[0].GetStatus())
+
+	ticketService.Drain()
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// RequireAdmin guards the admin routes with a shared token, sent as
+// "Authorization: Bearer <token>". A request without a token gets 401 and one
+// with the wrong token 403. When token is empty every request gets 403, so
+// the admin routes are closed unless a token is configured.
+//
+// The X-User-ID header is still what the admin handlers record as the actor.
+func RequireAdmin(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				writeError(w, r, http.StatusForbidden, "admin API is disabled")
+				return
+			}
+
+			given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || given == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				writeError(w, r, http.StatusUnauthorized, "missing admin token")
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				writeError(w, r, http.StatusForbidden, "invalid admin token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
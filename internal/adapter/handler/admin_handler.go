@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+type AdminHandler struct {
+	svc *services.AdminService
+}
+
+func NewAdminHandler(svc *services.AdminService) *AdminHandler {
+	return &AdminHandler{svc: svc}
+}
+
+// GetSeatHistory handles GET /v1/admin/seats/{seatID}/history.
+func (h *AdminHandler) GetSeatHistory(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	history, err := h.svc.GetSeatHistory(r.Context(), r.PathValue("seatID"), limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		writeError(w, r, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, history)
+}
+
+type setReservationModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+// SetReservationMode handles PUT /v1/admin/events/{eventID}/reservation-mode.
+func (h *AdminHandler) SetReservationMode(w http.ResponseWriter, r *http.Request) {
+	var req setReservationModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.svc.SetReservationMode(r.Context(), r.PathValue("eventID"), req.Mode); err != nil {
+		errMsg := err.Error()
+
+		if strings.Contains(errMsg, "not found") {
+			writeError(w, r, http.StatusNotFound, errMsg)
+		} else if strings.Contains(errMsg, "invalid") {
+			writeError(w, r, http.StatusBadRequest, errMsg)
+		} else {
+			writeError(w, r, http.StatusInternalServerError, "internal server error")
+		}
+
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]string{"mode": strings.ToUpper(req.Mode)})
+}
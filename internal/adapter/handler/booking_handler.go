@@ -2,78 +2,225 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/srgjo27/scalable_ticket/internal/core/services"
 )
 
+// UserIDHeader identifies the caller until real authentication exists.
+const UserIDHeader = "X-User-ID"
+
+// queueFullRetryAfter is the Retry-After, in seconds, sent when the Redis
+// reservation queue is full; the writers drain it within about that long.
+const queueFullRetryAfter = "1"
+
 type BookingHandler struct {
-	svc *services.BookingService
+	svc     *services.BookingService
+	limiter *RateLimiter
 }
 
 func NewBookingHandler(svc *services.BookingService) *BookingHandler {
 	return &BookingHandler{svc: svc}
 }
 
-func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+// SetRateLimiter limits booking attempts and seat reads. Without this call
+// nothing is limited.
+func (h *BookingHandler) SetRateLimiter(l *RateLimiter) {
+	h.limiter = l
+}
 
-	if r.Method != http.MethodPost {
-		http.Error(w, `{"error": "method not allowed}`, http.StatusMethodNotAllowed)
+type createBookingRequest struct {
+	SeatIDs []string `json:"seat_ids"`
+}
+
+// CreateBooking handles POST /v1/events/{eventID}/bookings for the caller
+// named by X-User-ID.
+func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	var req services.CreateBookingRequest
+	var req createBookingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "invalid json body"})
+		writeDecodeError(w, r, err)
 		return
 	}
 
-	resp, err := h.svc.CreateBooking(r.Context(), req)
+	h.createBooking(w, r, services.CreateBookingRequest{
+		UserID:  userID,
+		EventID: r.PathValue("eventID"),
+		SeatIDs: req.SeatIDs,
+	})
+}
 
+func (h *BookingHandler) createBooking(w http.ResponseWriter, r *http.Request, req services.CreateBookingRequest) {
+	// Validate first, so malformed IDs never become rate limit keys.
+	var invalid *services.ValidationError
+	if errors.As(req.Validate(), &invalid) {
+		writeValidationError(w, r, invalid)
+		return
+	}
+
+	if !h.limiter.allowBooking(w, r, req.UserID, req.EventID) {
+		return
+	}
+
+	resp, err := h.svc.CreateBooking(r.Context(), req)
 	if err != nil {
 		errMsg := err.Error()
 
-		if strings.Contains(errMsg, "seat not found") || strings.Contains(errMsg, "not available") {
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{"error": errMsg})
+		if errors.As(err, &invalid) {
+			writeValidationError(w, r, invalid)
+		} else if errors.Is(err, services.ErrSeatUnavailable) || strings.Contains(errMsg, "seat not found") {
+			writeError(w, r, http.StatusConflict, errMsg)
 		} else if strings.Contains(errMsg, "invalid") {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{"error": errMsg})
+			writeError(w, r, http.StatusBadRequest, errMsg)
+		} else if errors.Is(err, services.ErrBookingQueueFull) {
+			w.Header().Set("Retry-After", queueFullRetryAfter)
+			writeError(w, r, http.StatusServiceUnavailable, errMsg)
 		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{"error": "internal server error"})
+			writeError(w, r, http.StatusInternalServerError, "internal server error")
 		}
 
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-
-	}
+	writeJSON(w, r, http.StatusCreated, resp)
 }
 
+// GetSeats handles GET /v1/events/{eventID}/seats.
 func (h *BookingHandler) GetSeats(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	h.getSeats(w, r, r.PathValue("eventID"))
+}
+
+func (h *BookingHandler) getSeats(w http.ResponseWriter, r *http.Request, eventID string) {
+	if !h.limiter.allowSeatRead(w, r) {
 		return
 	}
 
-	eventID := r.URL.Query().Get("event_id")
 	if eventID == "" {
-		http.Error(w, "missing event_id", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, "missing event_id")
 		return
 	}
 
-	seats, err := h.svc.GetAvailableSeats(r.Context(), eventID)
+	// /v1 lists seats in snake_case like every other response; the legacy
+	// route keeps its PascalCase domain shape.
+	var seats any
+	var err error
+	if isV1(r) {
+		seats, err = h.svc.ListAvailableSeats(r.Context(), eventID)
+	} else {
+		seats, err = h.svc.GetAvailableSeats(r.Context(), eventID)
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeQueryError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, seats)
+}
+
+// GetBooking handles GET /v1/bookings/{bookingID}.
+func (h *BookingHandler) GetBooking(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(seats)
+	resp, err := h.svc.GetBooking(r.Context(), userID, r.PathValue("bookingID"))
+	if err != nil {
+		writeQueryError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// ListMyBookings handles GET /v1/users/me/bookings.
+func (h *BookingHandler) ListMyBookings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	resp, err := h.svc.ListUserBookings(r.Context(), services.ListBookingsRequest{
+		UserID:  userID,
+		Status:  query.Get("status"),
+		EventID: query.Get("event_id"),
+		Cursor:  query.Get("cursor"),
+		Limit:   limit,
+	})
+	if err != nil {
+		writeQueryError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// requireUser returns the X-User-ID header, answering 401 when it is missing.
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.Header.Get(UserIDHeader)
+	if userID == "" {
+		writeError(w, r, http.StatusUnauthorized, "missing "+UserIDHeader+" header")
+		return "", false
+	}
+
+	return userID, true
+}
+
+func writeQueryError(w http.ResponseWriter, r *http.Request, err error) {
+	errMsg := err.Error()
+
+	if strings.Contains(errMsg, "not found") {
+		writeError(w, r, http.StatusNotFound, errMsg)
+	} else if strings.Contains(errMsg, "invalid") {
+		writeError(w, r, http.StatusBadRequest, errMsg)
+	} else {
+		writeError(w, r, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// GetSeatMap handles GET /v1/events/{eventID}/seatmap. The body is encoded
+// once per seat map version, so the /v1 envelope is added around it here.
+func (h *BookingHandler) GetSeatMap(w http.ResponseWriter, r *http.Request) {
+	if !h.limiter.allowSeatRead(w, r) {
+		return
+	}
+
+	includeIDs := r.URL.Query().Get("include") == "ids"
+
+	seatMap, err := h.svc.GetSeatMap(r.Context(), r.PathValue("eventID"), includeIDs)
+	if err != nil {
+		writeQueryError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", seatMap.ETag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, seatMap.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, json.RawMessage(seatMap.Body))
+}
+
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,180 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	demoEventID = "b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22"
+	demoSeatID  = "c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a01"
+	demoSeat2ID = "c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a02"
+	demoUserID  = "d1eebc99-9c0b-4ef8-bb6d-6bb9bd380a55"
+)
+
+func newDemoBookingHandler(t *testing.T) *handler.BookingHandler {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	svc := services.NewBookingService(memory.NewSeatRepository(store), memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache())
+
+	return handler.NewBookingHandler(svc)
+}
+
+func TestBookingHandler_V1Envelope(t *testing.T) {
+	h := newDemoBookingHandler(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/events/{eventID}/bookings", h.CreateBooking)
+	mux.HandleFunc("GET /v1/events/{eventID}/seats", h.GetSeats)
+
+	seats := httptest.NewRecorder()
+	mux.ServeHTTP(seats, httptest.NewRequest(http.MethodGet, "/v1/events/"+demoEventID+"/seats", nil))
+
+	require.Equal(t, http.StatusOK, seats.Code)
+	assert.NotContains(t, seats.Body.String(), `"SeatNumber"`)
+	var listed struct {
+		Data []services.SeatResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(seats.Body.Bytes(), &listed))
+	require.Len(t, listed.Data, 2)
+	assert.Equal(t, demoSeatID, listed.Data[0].SeatID)
+	assert.Equal(t, "1", listed.Data[0].SeatNumber)
+	assert.Equal(t, "AVAILABLE", listed.Data[0].Status)
+
+	book := func(userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/events/"+demoEventID+"/bookings", strings.NewReader(`{"seat_ids":["`+demoSeatID+`"]}`))
+		if userID != "" {
+			req.Header.Set(handler.UserIDHeader, userID)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	anonymous := book("")
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	assert.JSONEq(t, `{"error":{"code":"unauthorized","message":"missing X-User-ID header"}}`, anonymous.Body.String())
+
+	created := book(demoUserID)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var booking struct {
+		Data services.CreateBookingResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &booking))
+	assert.NotEmpty(t, booking.Data.BookingID)
+	assert.Equal(t, "PENDING", booking.Data.Status)
+
+	badEvent := httptest.NewRecorder()
+	mux.ServeHTTP(badEvent, httptest.NewRequest(http.MethodGet, "/v1/events/not-a-uuid/seats", nil))
+	assert.Equal(t, http.StatusBadRequest, badEvent.Code)
+	assert.Contains(t, badEvent.Body.String(), `"code":"bad_request"`)
+}
+
+func TestBookingHandler_ValidationErrorListsFields(t *testing.T) {
+	h := newDemoBookingHandler(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/events/{eventID}/bookings", h.CreateBooking)
+	mux.HandleFunc("POST /bookings", h.LegacyCreateBooking)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/events/not-a-uuid/bookings", strings.NewReader(`{"seat_ids":["`+demoSeatID+`","A1"]}`))
+	req.Header.Set(handler.UserIDHeader, demoUserID)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error":{
+		"code":"bad_request",
+		"message":"invalid request: event_id must be a UUID; seat_ids[1] must be a UUID",
+		"fields":[
+			{"field":"event_id","message":"must be a UUID"},
+			{"field":"seat_ids[1]","message":"must be a UUID"}
+		]
+	}}`, rec.Body.String())
+
+	legacy := httptest.NewRecorder()
+	mux.ServeHTTP(legacy, httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(`{"event_id":"`+demoEventID+`","seat_ids":[]}`)))
+
+	assert.Equal(t, http.StatusBadRequest, legacy.Code)
+	assert.JSONEq(t, `{"error":"invalid request: user_id is required; seat_ids must list at least one seat"}`, legacy.Body.String())
+}
+
+func TestBookingHandler_LegacyRoutesKeepFormat(t *testing.T) {
+	h := newDemoBookingHandler(t)
+	sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /bookings", handler.Deprecated(sunset)(http.HandlerFunc(h.LegacyCreateBooking)))
+	mux.Handle("GET /seats", handler.Deprecated(sunset)(http.HandlerFunc(h.LegacyGetSeats)))
+
+	body := `{"user_id":"` + demoUserID + `","event_id":"` + demoEventID + `","seat_ids":["` + demoSeatID + `"]}`
+	created := httptest.NewRecorder()
+	mux.ServeHTTP(created, httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(body)))
+
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var booking services.CreateBookingResponse
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &booking))
+	assert.NotEmpty(t, booking.BookingID, "the body is not wrapped in an envelope")
+	assert.Equal(t, "@"+strconv.FormatInt(handler.LegacyDeprecatedAt.Unix(), 10), created.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", created.Header().Get("Sunset"))
+
+	missing := httptest.NewRecorder()
+	mux.ServeHTTP(missing, httptest.NewRequest(http.MethodGet, "/seats", nil))
+	assert.Equal(t, http.StatusBadRequest, missing.Code)
+	assert.JSONEq(t, `{"error":"missing event_id"}`, missing.Body.String())
+}
+
+// contendedSeatRepository lets a rival request lock and roll back a seat
+// between loser reading it and locking it, so loser's optimistic lock fails.
+type contendedSeatRepository struct {
+	*memory.SeatRepository
+	loser uuid.UUID
+}
+
+func (r contendedSeatRepository) LockSeat(ctx context.Context, seatID, bookingID, actorID uuid.UUID, currentVersion int) error {
+	if actorID == r.loser {
+		rival := uuid.New()
+		if err := r.SeatRepository.LockSeat(ctx, seatID, rival, rival, currentVersion); err != nil {
+			return err
+		}
+		if err := r.SeatRepository.UnlockSeat(ctx, seatID, rival, rival); err != nil {
+			return err
+		}
+	}
+
+	return r.SeatRepository.LockSeat(ctx, seatID, bookingID, actorID, currentVersion)
+}
+
+func TestBookingHandler_LostSeatLockIsConflict(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	seatRepo := contendedSeatRepository{memory.NewSeatRepository(store), uuid.MustParse(demoUserID)}
+	h := handler.NewBookingHandler(services.NewBookingService(seatRepo, memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/events/{eventID}/bookings", h.CreateBooking)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/events/"+demoEventID+"/bookings", strings.NewReader(`{"seat_ids":["`+demoSeatID+`"]}`))
+	req.Header.Set(handler.UserIDHeader, demoUserID)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"conflict","message":"seat 1 is not available"}}`, rec.Body.String())
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+// HoldHandler serves the admin seat hold API. The admin named by X-User-ID is
+// recorded as the actor of every change.
+type HoldHandler struct {
+	svc *services.HoldService
+}
+
+func NewHoldHandler(svc *services.HoldService) *HoldHandler {
+	return &HoldHandler{svc: svc}
+}
+
+type createHoldRequest struct {
+	Name      string   `json:"name"`
+	Reason    string   `json:"reason"`
+	Owner     string   `json:"owner"`
+	SeatIDs   []string `json:"seat_ids"`
+	ReleaseAt string   `json:"release_at"`
+}
+
+// CreateHold handles POST /v1/admin/events/{eventID}/holds.
+func (h *HoldHandler) CreateHold(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req createHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	hold, err := h.svc.CreateHold(r.Context(), services.CreateHoldRequest{
+		ActorID:   actorID,
+		EventID:   r.PathValue("eventID"),
+		Name:      req.Name,
+		Reason:    req.Reason,
+		Owner:     req.Owner,
+		SeatIDs:   req.SeatIDs,
+		ReleaseAt: req.ReleaseAt,
+	})
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, hold)
+}
+
+// ListHolds handles GET /v1/admin/events/{eventID}/holds.
+func (h *HoldHandler) ListHolds(w http.ResponseWriter, r *http.Request) {
+	holds, err := h.svc.ListHolds(r.Context(), r.PathValue("eventID"), r.URL.Query().Get("status"))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, holds)
+}
+
+// GetHold handles GET /v1/admin/holds/{holdID}.
+func (h *HoldHandler) GetHold(w http.ResponseWriter, r *http.Request) {
+	hold, err := h.svc.GetHold(r.Context(), r.PathValue("holdID"))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, hold)
+}
+
+// ReleaseHold handles POST /v1/admin/holds/{holdID}/release.
+func (h *HoldHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.svc.ReleaseHold(r.Context(), actorID, r.PathValue("holdID"))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, hold)
+}
+
+type convertHoldRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// ConvertHold handles POST /v1/admin/holds/{holdID}/convert.
+func (h *HoldHandler) ConvertHold(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req convertHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	hold, err := h.svc.ConvertHold(r.Context(), services.ConvertHoldRequest{
+		ActorID: actorID,
+		HoldID:  r.PathValue("holdID"),
+		UserID:  req.UserID,
+	})
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, hold)
+}
+
+// writeHoldError maps seats that changed under a hold and holds that are
+// already closed to 409, unknown holds to 404 and bad input to 400.
+func writeHoldError(w http.ResponseWriter, r *http.Request, err error) {
+	errMsg := err.Error()
+
+	var invalid *services.ValidationError
+	if errors.As(err, &invalid) {
+		writeValidationError(w, r, invalid)
+	} else if errors.Is(err, ports.ErrHoldNotActive) || strings.Contains(errMsg, "seat not found") ||
+		strings.Contains(errMsg, "not available") || strings.Contains(errMsg, "not held") {
+		writeError(w, r, http.StatusConflict, errMsg)
+	} else if strings.Contains(errMsg, "not found") {
+		writeError(w, r, http.StatusNotFound, errMsg)
+	} else if strings.Contains(errMsg, "invalid") {
+		writeError(w, r, http.StatusBadRequest, errMsg)
+	} else {
+		writeError(w, r, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,173 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "test-admin-token-0001"
+
+func TestHoldHandler_HeldSeatsAreOffSaleUntilReleased(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	seatRepo := memory.NewSeatRepository(store)
+	bookingHandler := handler.NewBookingHandler(services.NewBookingService(seatRepo, memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache()))
+	holdHandler := handler.NewHoldHandler(services.NewHoldService(memory.NewHoldRepository(store), seatRepo, noop.NewSeatCache(), noop.NewCache()))
+	adminHandler := handler.NewAdminHandler(services.NewAdminService(memory.NewSeatAuditRepository(store), memory.NewEventRepository(store)))
+
+	requireAdmin := handler.RequireAdmin(testAdminToken)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/events/{eventID}/seats", bookingHandler.GetSeats)
+	mux.HandleFunc("POST /v1/events/{eventID}/bookings", bookingHandler.CreateBooking)
+	mux.Handle("POST /v1/admin/events/{eventID}/holds", requireAdmin(http.HandlerFunc(holdHandler.CreateHold)))
+	mux.Handle("POST /v1/admin/holds/{holdID}/release", requireAdmin(http.HandlerFunc(holdHandler.ReleaseHold)))
+	mux.Handle("GET /v1/admin/seats/{seatID}/history", requireAdmin(http.HandlerFunc(adminHandler.GetSeatHistory)))
+
+	do := func(method, target, userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if userID != "" {
+			req.Header.Set(handler.UserIDHeader, userID)
+		}
+		if strings.HasPrefix(target, "/v1/admin/") {
+			req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	availableSeats := func() []string {
+		rec := do(http.MethodGet, "/v1/events/"+demoEventID+"/seats", "", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var listed struct {
+			Data []services.SeatResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+
+		var ids []string
+		for _, seat := range listed.Data {
+			ids = append(ids, seat.SeatID)
+		}
+		return ids
+	}
+
+	admin := uuid.NewString()
+	created := do(http.MethodPost, "/v1/admin/events/"+demoEventID+"/holds", admin,
+		`{"name":"Artist guests","reason":"Guest list","owner":"artist","seat_ids":["`+demoSeatID+`"]}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var hold struct {
+		Data services.HoldResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &hold))
+	assert.Equal(t, "ACTIVE", hold.Data.Status)
+	assert.Nil(t, hold.Data.ReleaseAt)
+
+	assert.Equal(t, []string{demoSeat2ID}, availableSeats())
+
+	booked := do(http.MethodPost, "/v1/events/"+demoEventID+"/bookings", demoUserID, `{"seat_ids":["`+demoSeatID+`"]}`)
+	assert.Equal(t, http.StatusConflict, booked.Code)
+
+	released := do(http.MethodPost, "/v1/admin/holds/"+hold.Data.HoldID+"/release", admin, "")
+	require.Equal(t, http.StatusOK, released.Code, released.Body.String())
+	assert.Contains(t, released.Body.String(), `"status":"RELEASED"`)
+
+	assert.ElementsMatch(t, []string{demoSeatID, demoSeat2ID}, availableSeats())
+
+	history := do(http.MethodGet, "/v1/admin/seats/"+demoSeatID+"/history", "", "")
+	require.Equal(t, http.StatusOK, history.Code)
+
+	var entries struct {
+		Data []services.SeatHistoryEntry `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(history.Body.Bytes(), &entries))
+	require.Len(t, entries.Data, 2)
+	for _, entry := range entries.Data {
+		require.NotNil(t, entry.HoldID)
+		assert.Equal(t, hold.Data.HoldID, *entry.HoldID)
+		assert.Equal(t, admin, *entry.ChangedByUserID)
+	}
+	assert.Equal(t, "hold released", entries.Data[0].Reason)
+	assert.Equal(t, "seat held", entries.Data[1].Reason)
+}
+
+func TestHoldHandler_AdminRoutesRejectNonAdmins(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	holdHandler := handler.NewHoldHandler(services.NewHoldService(memory.NewHoldRepository(store), memory.NewSeatRepository(store), noop.NewSeatCache(), noop.NewCache()))
+	requireAdmin := handler.RequireAdmin(testAdminToken)
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /v1/admin/events/{eventID}/holds", requireAdmin(http.HandlerFunc(holdHandler.CreateHold)))
+	mux.Handle("GET /v1/admin/events/{eventID}/holds", requireAdmin(http.HandlerFunc(holdHandler.ListHolds)))
+	mux.Handle("POST /v1/admin/holds/{holdID}/release", requireAdmin(http.HandlerFunc(holdHandler.ReleaseHold)))
+	mux.Handle("POST /v1/admin/holds/{holdID}/convert", requireAdmin(http.HandlerFunc(holdHandler.ConvertHold)))
+
+	do := func(method, target, authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(handler.UserIDHeader, demoUserID)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	holds := "/v1/admin/events/" + demoEventID + "/holds"
+	created := do(http.MethodPost, holds, "Bearer "+testAdminToken,
+		`{"name":"Artist guests","reason":"Guest list","owner":"artist","seat_ids":["`+demoSeatID+`"]}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+	var hold struct {
+		Data services.HoldResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &hold))
+	heldPath := "/v1/admin/holds/" + hold.Data.HoldID
+
+	routes := []struct {
+		name, target, body string
+	}{
+		{"create", holds, `{"name":"Sneaky","seat_ids":["` + demoSeat2ID + `"]}`},
+		{"release", heldPath + "/release", ""},
+		{"convert", heldPath + "/convert", `{"user_id":"` + demoUserID + `"}`},
+	}
+
+	for _, route := range routes {
+		t.Run(route.name, func(t *testing.T) {
+			anonymous := do(http.MethodPost, route.target, "", route.body)
+			assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+			assert.Equal(t, `Bearer realm="admin"`, anonymous.Header().Get("WWW-Authenticate"))
+			assert.JSONEq(t, `{"error":{"code":"unauthorized","message":"missing admin token"}}`, anonymous.Body.String())
+
+			wrong := do(http.MethodPost, route.target, "Bearer not-the-admin-token", route.body)
+			assert.Equal(t, http.StatusForbidden, wrong.Code)
+			assert.JSONEq(t, `{"error":{"code":"forbidden","message":"invalid admin token"}}`, wrong.Body.String())
+		})
+	}
+
+	listed := do(http.MethodGet, holds, "Bearer "+testAdminToken, "")
+	require.Equal(t, http.StatusOK, listed.Code, listed.Body.String())
+
+	var active struct {
+		Data []services.HoldResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listed.Body.Bytes(), &active))
+	require.Len(t, active.Data, 1, "no hold was created")
+	assert.Equal(t, "ACTIVE", active.Data[0].Status, "the hold was neither released nor converted")
+}
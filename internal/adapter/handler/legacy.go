@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+// The unversioned routes predate /v1 and are kept, unchanged, until clients
+// have moved over. LegacyDeprecatedAt is the day /v1 shipped.
+var LegacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// LegacyCreateBooking handles the deprecated POST /bookings, which takes the
+// user and event in the body instead of the path and header.
+func (h *BookingHandler) LegacyCreateBooking(w http.ResponseWriter, r *http.Request) {
+	var req services.CreateBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	h.createBooking(w, r, req)
+}
+
+// LegacyGetSeats handles the deprecated GET /seats?event_id=.
+func (h *BookingHandler) LegacyGetSeats(w http.ResponseWriter, r *http.Request) {
+	h.getSeats(w, r, r.URL.Query().Get("event_id"))
+}
+
+// Deprecated marks responses with the Deprecation header (RFC 9745) and, when
+// sunset is set, the Sunset header (RFC 8594).
+func Deprecated(sunset time.Time) Middleware {
+	deprecation := "@" + strconv.FormatInt(LegacyDeprecatedAt.Unix(), 10)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
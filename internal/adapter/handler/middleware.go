@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+// Middleware wraps a handler with cross-cutting behaviour.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h in middleware, the first being the outermost.
+func Chain(h http.Handler, middleware ...Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+// Recover turns a panicking handler into a 500 with the usual JSON error body
+// and logs the panic with its stack. If the handler had already started
+// writing, the response can't be repaired, so the connection is aborted
+// instead.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			ctx := r.Context()
+			logging.FromContext(ctx).ErrorContext(ctx, "Handler panicked",
+				"panic", v,
+				"stack", string(debug.Stack()),
+			)
+
+			if rec.wroteHeader {
+				panic(http.ErrAbortHandler)
+			}
+
+			writeError(w, r, http.StatusInternalServerError, "internal server error")
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// Timeout cancels the request context after d and answers 503 if the handler
+// hasn't responded by then. The response is buffered until the handler
+// returns, so use it on API routes only, not on streaming endpoints.
+func Timeout(d time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		// TimeoutHandler's body is fixed, so keep one per response format.
+		legacy := http.TimeoutHandler(next, d, timeoutBody(false))
+		v1 := http.TimeoutHandler(next, d, timeoutBody(true))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// TimeoutHandler writes its body without a Content-Type; a
+			// handler that responds in time overrides this with its own.
+			w.Header().Set("Content-Type", "application/json")
+
+			if isV1(r) {
+				v1.ServeHTTP(w, r)
+				return
+			}
+
+			legacy.ServeHTTP(w, r)
+		})
+	}
+}
+
+func timeoutBody(v1 bool) string {
+	body, _ := json.Marshal(errorResponse(v1, http.StatusServiceUnavailable, "request timed out"))
+
+	return string(body) + "\n"
+}
+
+// LimitBody rejects request bodies larger than n bytes. A declared
+// Content-Length over the limit is refused up front; otherwise reading past
+// the limit fails with *http.MaxBytesError.
+func LimitBody(n int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				writeError(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RouteErrors answers requests that match no route with the usual JSON error
+// body instead of ServeMux's plain-text 404 and 405. The Allow header of a
+// 405 is kept.
+func RouteErrors(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		// Let the mux pick the status and set Allow, then replace its body.
+		rec := &headerOnlyRecorder{header: w.Header(), status: http.StatusNotFound}
+		h.ServeHTTP(rec, r)
+
+		writeError(w, r, rec.status, strings.ToLower(http.StatusText(rec.status)))
+	})
+}
+
+// headerOnlyRecorder shares the real response headers but discards the
+// status line and body.
+type headerOnlyRecorder struct {
+	header http.Header
+	status int
+}
+
+func (r *headerOnlyRecorder) Header() http.Header         { return r.header }
+func (r *headerOnlyRecorder) WriteHeader(status int)      { r.status = status }
+func (r *headerOnlyRecorder) Write(b []byte) (int, error) { return len(b), nil }
@@ -0,0 +1,171 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_FirstMiddlewareIsOutermost(t *testing.T) {
+	var order []string
+	tag := func(name string) handler.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := handler.Chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		order = append(order, "handler")
+	}), tag("outer"), tag("inner"))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"outer", "inner", "handler"}, order)
+}
+
+func TestRecover_WritesJSONError(t *testing.T) {
+	h := handler.Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("nil map")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/bookings", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"internal server error"}`, rec.Body.String())
+}
+
+func TestRecover_AbortsStartedResponse(t *testing.T) {
+	h := handler.Recover(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		panic("half written")
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestTimeout_AnswersJSON503(t *testing.T) {
+	h := handler.Timeout(10 * time.Millisecond)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/seats", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"request timed out"}`, rec.Body.String())
+
+	v1 := httptest.NewRecorder()
+	h.ServeHTTP(v1, httptest.NewRequest(http.MethodGet, "/v1/events/1/seats", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, v1.Code)
+	assert.JSONEq(t, `{"error":{"code":"service_unavailable","message":"request timed out"}}`, v1.Body.String())
+}
+
+func TestLimitBody_RejectsLargeBodies(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /bookings", handler.NewBookingHandler(nil).LegacyCreateBooking)
+	h := handler.LimitBody(16)(mux)
+
+	body := `{"user_id":"` + strings.Repeat("a", 64) + `"}`
+
+	declared := httptest.NewRecorder()
+	h.ServeHTTP(declared, httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(body)))
+
+	// Without a Content-Length the limit is only hit while decoding.
+	req := httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(body))
+	req.ContentLength = -1
+	streamed := httptest.NewRecorder()
+	h.ServeHTTP(streamed, req)
+
+	for _, rec := range []*httptest.ResponseRecorder{declared, streamed} {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.JSONEq(t, `{"error":"request body too large"}`, rec.Body.String())
+	}
+}
+
+func TestRouteErrors_AnswersJSON(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bookings/{id}", func(http.ResponseWriter, *http.Request) {})
+	h := handler.RouteErrors(mux)
+
+	notFound := httptest.NewRecorder()
+	h.ServeHTTP(notFound, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	assert.Equal(t, http.StatusNotFound, notFound.Code)
+	assert.JSONEq(t, `{"error":"not found"}`, notFound.Body.String())
+
+	notAllowed := httptest.NewRecorder()
+	h.ServeHTTP(notAllowed, httptest.NewRequest(http.MethodDelete, "/bookings/1", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, notAllowed.Code)
+	assert.Equal(t, "application/json", notAllowed.Header().Get("Content-Type"))
+	assert.Equal(t, "GET, HEAD", notAllowed.Header().Get("Allow"))
+	assert.JSONEq(t, `{"error":"method not allowed"}`, notAllowed.Body.String())
+}
+
+func TestCORS(t *testing.T) {
+	called := false
+	h := handler.CORS(handler.CORSConfig{
+		AllowedOrigins: []string{"https://shop.example"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type", "X-User-ID"},
+		ExposedHeaders: []string{"X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/bookings", nil)
+	preflight.Header.Set("Origin", "https://shop.example")
+	preflight.Header.Set("Access-Control-Request-Method", "POST")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.False(t, called, "preflight is answered by the middleware")
+	assert.Equal(t, "https://shop.example", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, X-User-ID", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+
+	simple := httptest.NewRequest(http.MethodGet, "/seats", nil)
+	simple.Header.Set("Origin", "https://shop.example")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, simple)
+
+	assert.True(t, called)
+	assert.Equal(t, "https://shop.example", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Request-ID", rec.Header().Get("Access-Control-Expose-Headers"))
+
+	other := httptest.NewRequest(http.MethodGet, "/seats", nil)
+	other.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, other)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestRequireAdmin_ClosedWithoutToken(t *testing.T) {
+	h := handler.RequireAdmin("")(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("handler must not run")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/seats/1/history", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"forbidden","message":"admin API is disabled"}}`, rec.Body.String())
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"net/http"
+)
+
+// openAPISpec describes every route. Change it together with the handlers;
+// TestOpenAPI_ResponsesMatchSpec fails when a response drifts from it.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+var openAPIETag = func() string {
+	sum := sha256.Sum256(openAPISpec)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}()
+
+// OpenAPI handles GET /openapi.json.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", openAPIETag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, openAPIETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
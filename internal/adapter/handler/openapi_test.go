@@ -0,0 +1,270 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	legacyrouter "github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+	memorycache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/memory"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/srgjo27/scalable_ticket/internal/platform/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contendedUserID always loses the seat lock to a concurrent request.
+const contendedUserID = "e1eebc99-9c0b-4ef8-bb6d-6bb9bd380a66"
+
+// newSpecRoutes mirrors the routes registered in cmd/api, backed by the
+// memory demo data.
+func newSpecRoutes(t *testing.T) http.Handler {
+	store := memory.NewStore()
+	require.NoError(t, memory.SeedDemo(store))
+
+	seatRepo := contendedSeatRepository{memory.NewSeatRepository(store), uuid.MustParse(contendedUserID)}
+	bookingService := services.NewBookingService(seatRepo, memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache())
+	adminService := services.NewAdminService(memory.NewSeatAuditRepository(store), memory.NewEventRepository(store))
+	holdService := services.NewHoldService(memory.NewHoldRepository(store), memory.NewSeatRepository(store), noop.NewSeatCache(), noop.NewCache())
+
+	bookingHandler := handler.NewBookingHandler(bookingService)
+	bookingHandler.SetRateLimiter(handler.NewRateLimiter(memorycache.NewRateLimiter(), handler.RateLimitPolicies{
+		BookingPerUserEvent: ports.RateLimit{Limit: 1, Window: time.Minute},
+	}))
+	adminHandler := handler.NewAdminHandler(adminService)
+	holdHandler := handler.NewHoldHandler(holdService)
+	healthHandler := handler.NewHealthHandler(health.NewChecker(time.Second))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/events/{eventID}/bookings", bookingHandler.CreateBooking)
+	mux.HandleFunc("GET /v1/events/{eventID}/seats", bookingHandler.GetSeats)
+	mux.HandleFunc("GET /v1/events/{eventID}/seatmap", bookingHandler.GetSeatMap)
+	mux.HandleFunc("GET /v1/bookings/{bookingID}", bookingHandler.GetBooking)
+	mux.HandleFunc("GET /v1/users/me/bookings", bookingHandler.ListMyBookings)
+
+	requireAdmin := handler.RequireAdmin(testAdminToken)
+	adminRoute := func(pattern string, h http.HandlerFunc) {
+		mux.Handle(pattern, requireAdmin(h))
+	}
+	adminRoute("GET /v1/admin/seats/{seatID}/history", adminHandler.GetSeatHistory)
+	adminRoute("PUT /v1/admin/events/{eventID}/reservation-mode", adminHandler.SetReservationMode)
+	adminRoute("POST /v1/admin/events/{eventID}/holds", holdHandler.CreateHold)
+	adminRoute("GET /v1/admin/events/{eventID}/holds", holdHandler.ListHolds)
+	adminRoute("GET /v1/admin/holds/{holdID}", holdHandler.GetHold)
+	adminRoute("POST /v1/admin/holds/{holdID}/release", holdHandler.ReleaseHold)
+	adminRoute("POST /v1/admin/holds/{holdID}/convert", holdHandler.ConvertHold)
+
+	deprecated := handler.Deprecated(time.Time{})
+	legacy := func(pattern string, h http.HandlerFunc) {
+		mux.Handle(pattern, deprecated(h))
+	}
+	legacy("POST /bookings", bookingHandler.LegacyCreateBooking)
+	legacy("GET /seats", bookingHandler.LegacyGetSeats)
+
+	mux.HandleFunc("GET /healthz", healthHandler.Healthz)
+	mux.HandleFunc("GET /readyz", healthHandler.Readyz)
+	mux.HandleFunc("GET /openapi.json", handler.OpenAPI)
+
+	return handler.LimitBody(1024)(mux)
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	rec := httptest.NewRecorder()
+	handler.OpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(rec.Body.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	return doc
+}
+
+func TestOpenAPI_ResponsesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+	router, err := legacyrouter.NewRouter(doc)
+	require.NoError(t, err)
+
+	routes := newSpecRoutes(t)
+	exercised := map[string]bool{}
+
+	// adminAuth is sent as the Authorization header of admin routes.
+	adminAuth := "Bearer " + testAdminToken
+
+	// call sends one request through the routes and checks the response
+	// against the operation the spec documents for it.
+	call := func(method, target, userID, body string, wantStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if userID != "" {
+			req.Header.Set(handler.UserIDHeader, userID)
+		}
+		if strings.Contains(target, "/admin/") && adminAuth != "" {
+			req.Header.Set("Authorization", adminAuth)
+		}
+
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, req)
+		require.Equal(t, wantStatus, rec.Code, "%s %s: %s", method, target, rec.Body.String())
+
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err, "%s %s is not in the spec", method, target)
+		exercised[route.Method+" "+route.Path] = true
+
+		err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route},
+			Status:                 rec.Code,
+			Header:                 rec.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		})
+		require.NoError(t, err, "%s %s answered %d outside the spec", method, target, rec.Code)
+
+		return rec
+	}
+
+	firstUser, secondUser := uuid.NewString(), uuid.NewString()
+	unknown := uuid.NewString()
+	seat := func(id string) string { return `{"seat_ids":["` + id + `"]}` }
+
+	call("GET", "/v1/events/"+demoEventID+"/seats", "", "", http.StatusOK)
+	call("GET", "/v1/events/not-a-uuid/seats", "", "", http.StatusBadRequest)
+
+	seatMap := call("GET", "/v1/events/"+demoEventID+"/seatmap?include=ids", "", "", http.StatusOK)
+	req := httptest.NewRequest(http.MethodGet, "/v1/events/"+demoEventID+"/seatmap?include=ids", nil)
+	req.Header.Set("If-None-Match", seatMap.Header().Get("ETag"))
+	notModified := httptest.NewRecorder()
+	routes.ServeHTTP(notModified, req)
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	call("GET", "/v1/events/"+unknown+"/seatmap", "", "", http.StatusNotFound)
+
+	bookings := "/v1/events/" + demoEventID + "/bookings"
+	call("POST", bookings, "", seat(demoSeatID), http.StatusUnauthorized)
+	created := call("POST", bookings, firstUser, seat(demoSeatID), http.StatusCreated)
+	call("POST", bookings, secondUser, seat(demoSeatID), http.StatusConflict)
+	call("POST", bookings, contendedUserID, seat(demoSeat2ID), http.StatusConflict)
+	call("POST", bookings, firstUser, seat(demoSeatID), http.StatusTooManyRequests)
+	call("POST", bookings, uuid.NewString(), "{", http.StatusBadRequest)
+	call("POST", bookings, uuid.NewString(), `{"seat_ids":["A1",""]}`, http.StatusBadRequest)
+	call("POST", bookings, uuid.NewString(), seat(strings.Repeat("x", 2048)), http.StatusRequestEntityTooLarge)
+
+	var booking struct {
+		Data services.CreateBookingResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &booking))
+
+	call("GET", "/v1/bookings/"+booking.Data.BookingID, firstUser, "", http.StatusOK)
+	call("GET", "/v1/bookings/"+booking.Data.BookingID, "", "", http.StatusUnauthorized)
+	call("GET", "/v1/bookings/"+booking.Data.BookingID, secondUser, "", http.StatusNotFound)
+	call("GET", "/v1/users/me/bookings?limit=1", firstUser, "", http.StatusOK)
+	call("GET", "/v1/users/me/bookings?status=LOST", firstUser, "", http.StatusBadRequest)
+
+	call("GET", "/v1/admin/seats/"+demoSeatID+"/history", "", "", http.StatusOK)
+	call("GET", "/v1/admin/seats/not-a-uuid/history", "", "", http.StatusBadRequest)
+	call("PUT", "/v1/admin/events/"+demoEventID+"/reservation-mode", "", `{"mode":"database"}`, http.StatusOK)
+	call("PUT", "/v1/admin/events/"+demoEventID+"/reservation-mode", "", `{"mode":"paper"}`, http.StatusBadRequest)
+	call("PUT", "/v1/admin/events/"+unknown+"/reservation-mode", "", `{"mode":"REDIS"}`, http.StatusNotFound)
+
+	admin := uuid.NewString()
+	holds := "/v1/admin/events/" + demoEventID + "/holds"
+	hold := func(seatID string) string {
+		return `{"name":"Camera platform","reason":"Broadcast","owner":"production","seat_ids":["` + seatID + `"],"release_at":"2099-01-01T00:00:00Z"}`
+	}
+	call("POST", holds, "", hold(demoSeat2ID), http.StatusUnauthorized)
+	call("POST", holds, admin, `{"name":" ","seat_ids":["A1"]}`, http.StatusBadRequest)
+	call("POST", holds, admin, hold(demoSeatID), http.StatusConflict)
+	createdHold := call("POST", holds, admin, hold(demoSeat2ID), http.StatusCreated)
+	call("POST", holds, admin, hold(demoSeat2ID), http.StatusConflict)
+	call("GET", holds+"?status=active", "", "", http.StatusOK)
+	call("GET", holds+"?status=lost", "", "", http.StatusBadRequest)
+
+	var held struct {
+		Data services.HoldResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(createdHold.Body.Bytes(), &held))
+	heldPath := "/v1/admin/holds/" + held.Data.HoldID
+
+	call("GET", heldPath, "", "", http.StatusOK)
+	call("GET", "/v1/admin/holds/"+unknown, "", "", http.StatusNotFound)
+	call("GET", "/v1/admin/holds/not-a-uuid", "", "", http.StatusBadRequest)
+	call("POST", heldPath+"/convert", "", `{"user_id":"`+firstUser+`"}`, http.StatusUnauthorized)
+	call("POST", heldPath+"/convert", admin, `{}`, http.StatusBadRequest)
+	call("POST", heldPath+"/convert", admin, `{"user_id":"`+firstUser+`"}`, http.StatusOK)
+	call("POST", heldPath+"/convert", admin, `{"user_id":"`+firstUser+`"}`, http.StatusConflict)
+	call("POST", heldPath+"/release", "", "", http.StatusUnauthorized)
+	call("POST", heldPath+"/release", admin, "", http.StatusConflict)
+	call("POST", "/v1/admin/holds/"+unknown+"/release", admin, "", http.StatusNotFound)
+
+	adminAuth = ""
+	call("POST", holds, admin, hold(demoSeat2ID), http.StatusUnauthorized)
+	call("GET", "/v1/admin/seats/"+demoSeatID+"/history", "", "", http.StatusUnauthorized)
+	adminAuth = "Bearer not-the-admin-token"
+	call("POST", "/v1/admin/holds/"+unknown+"/release", admin, "", http.StatusForbidden)
+	call("PUT", "/v1/admin/events/"+demoEventID+"/reservation-mode", "", `{"mode":"DATABASE"}`, http.StatusForbidden)
+	adminAuth = "Bearer " + testAdminToken
+	call("GET", "/v1/admin/seats/"+demoSeat2ID+"/history", "", "", http.StatusOK)
+
+	legacyBooking := func(eventID string) string {
+		return `{"user_id":"` + uuid.NewString() + `","event_id":"` + eventID + `","seat_ids":["` + demoSeatID + `"]}`
+	}
+	call("POST", "/bookings", "", legacyBooking(demoEventID), http.StatusConflict)
+	call("POST", "/bookings", "", legacyBooking("not-a-uuid"), http.StatusBadRequest)
+	call("GET", "/seats?event_id="+demoEventID, "", "", http.StatusOK)
+	call("GET", "/seats", "", "", http.StatusBadRequest)
+
+	// Only the baseline routes have unversioned forms.
+	for _, target := range []string{"/events/" + demoEventID + "/seatmap", "/bookings/" + booking.Data.BookingID, "/users/me/bookings", "/admin/seats/" + demoSeatID + "/history"} {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, target)
+	}
+
+	call("GET", "/healthz", "", "", http.StatusOK)
+	call("GET", "/readyz", "", "", http.StatusOK)
+	call("GET", "/openapi.json", "", "", http.StatusOK)
+
+	var missing []string
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			if !exercised[method+" "+path] {
+				missing = append(missing, method+" "+path)
+			}
+		}
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "documented operations the test never calls")
+}
+
+func TestOpenAPI_ServesETag(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handler.OpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	cached := httptest.NewRecorder()
+	handler.OpenAPI(cached, req)
+
+	assert.Equal(t, http.StatusNotModified, cached.Code)
+	assert.Empty(t, cached.Body.String())
+}
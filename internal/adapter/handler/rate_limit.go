@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+// RateLimitPolicies configures the limits on the booking endpoints. A policy
+// with a zero Limit is off.
+type RateLimitPolicies struct {
+	// BookingPerUserEvent limits booking attempts by one user for one event.
+	BookingPerUserEvent ports.RateLimit
+	// BookingPerEvent caps booking attempts for one event across all users.
+	BookingPerEvent ports.RateLimit
+	// SeatReadsPerIP limits seat list and seat map reads per client address.
+	SeatReadsPerIP ports.RateLimit
+	// ClientIPHeader names a header set by a trusted proxy, such as
+	// X-Forwarded-For, whose last entry is the client address. Empty uses the
+	// connection's remote address.
+	ClientIPHeader string
+}
+
+// RateLimiter applies RateLimitPolicies. Allowed responses carry the
+// RateLimit-* headers of the policy closest to its limit; limited ones get
+// 429 with Retry-After. A nil *RateLimiter allows everything.
+type RateLimiter struct {
+	limiter  ports.RateLimiter
+	policies RateLimitPolicies
+}
+
+func NewRateLimiter(limiter ports.RateLimiter, policies RateLimitPolicies) *RateLimiter {
+	return &RateLimiter{limiter: limiter, policies: policies}
+}
+
+type rateCheck struct {
+	policy string
+	key    string
+	limit  ports.RateLimit
+}
+
+// allowBooking checks the booking policies. IDs that don't parse aren't
+// counted; the service rejects them anyway.
+func (l *RateLimiter) allowBooking(w http.ResponseWriter, r *http.Request, userID, eventID string) bool {
+	if l == nil {
+		return true
+	}
+
+	event, err := uuid.Parse(eventID)
+	if err != nil {
+		return true
+	}
+
+	var checks []rateCheck
+	if user, err := uuid.Parse(userID); err == nil {
+		checks = append(checks, rateCheck{
+			policy: "booking_per_user_event",
+			key:    fmt.Sprintf("booking:user:%s:event:%s", user, event),
+			limit:  l.policies.BookingPerUserEvent,
+		})
+	}
+
+	checks = append(checks, rateCheck{
+		policy: "booking_per_event",
+		key:    fmt.Sprintf("booking:event:%s", event),
+		limit:  l.policies.BookingPerEvent,
+	})
+
+	return l.allow(w, r, checks...)
+}
+
+func (l *RateLimiter) allowSeatRead(w http.ResponseWriter, r *http.Request) bool {
+	if l == nil {
+		return true
+	}
+
+	return l.allow(w, r, rateCheck{
+		policy: "seat_reads_per_ip",
+		key:    "seats:ip:" + l.clientIP(r),
+		limit:  l.policies.SeatReadsPerIP,
+	})
+}
+
+// allow counts the request against every check and writes the 429 itself
+// when one is exhausted. If the limiter fails the request is allowed: losing
+// Redis must not stop ticket sales.
+func (l *RateLimiter) allow(w http.ResponseWriter, r *http.Request, checks ...rateCheck) bool {
+	ctx := r.Context()
+
+	var tightest *rateCheck
+	var tightestResult ports.RateLimitResult
+
+	for _, check := range checks {
+		if check.limit.Limit <= 0 {
+			continue
+		}
+
+		result, err := l.limiter.Allow(ctx, check.key, check.limit)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "Rate limiter unavailable, allowing request", "policy", check.policy, "error", err)
+			continue
+		}
+
+		if !result.Allowed {
+			logging.FromContext(ctx).DebugContext(ctx, "Request rate limited", "policy", check.policy, "retry_after", result.RetryAfter.String())
+
+			writeRateLimitHeaders(w.Header(), check.limit, result)
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			writeError(w, r, http.StatusTooManyRequests, "rate limit exceeded")
+			return false
+		}
+
+		if tightest == nil || result.Remaining < tightestResult.Remaining {
+			tightest = &check
+			tightestResult = result
+		}
+	}
+
+	if tightest != nil {
+		writeRateLimitHeaders(w.Header(), tightest.limit, tightestResult)
+	}
+
+	return true
+}
+
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.policies.ClientIPHeader != "" {
+		if values := r.Header.Values(l.policies.ClientIPHeader); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// writeRateLimitHeaders sets the RateLimit-* headers from the IETF
+// RateLimit header fields draft. Times are whole seconds, rounded up.
+func writeRateLimitHeaders(h http.Header, limit ports.RateLimit, result ports.RateLimitResult) {
+	h.Set("RateLimit-Limit", strconv.Itoa(limit.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Limit, ceilSeconds(limit.Window)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	memorycache "github.com/srgjo27/scalable_ticket/internal/adapter/cache/memory"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/handler"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimiter_SeatReadsPerIP(t *testing.T) {
+	h := handler.NewBookingHandler(nil)
+	h.SetRateLimiter(handler.NewRateLimiter(memorycache.NewRateLimiter(), handler.RateLimitPolicies{
+		SeatReadsPerIP: ports.RateLimit{Limit: 2, Window: time.Minute},
+		ClientIPHeader: "X-Forwarded-For",
+	}))
+
+	read := func(forwardedFor string) *httptest.ResponseRecorder {
+		// Without event_id the request stops at validation, after the limit.
+		req := httptest.NewRequest(http.MethodGet, "/seats", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		h.LegacyGetSeats(rec, req)
+		return rec
+	}
+
+	first := read("10.0.0.9, 203.0.113.7")
+	assert.Equal(t, http.StatusBadRequest, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+
+	read("203.0.113.7")
+	limited := read("10.0.0.10, 203.0.113.7")
+
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+	assert.JSONEq(t, `{"error":"rate limit exceeded"}`, limited.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, read("198.51.100.1").Code, "other clients are unaffected")
+}
+
+func TestRateLimiter_BookingPerUserEvent(t *testing.T) {
+	store := memory.NewStore()
+	svc := services.NewBookingService(memory.NewSeatRepository(store), memory.NewBookingRepository(store), noop.NewSeatCache(), noop.NewCache())
+
+	h := handler.NewBookingHandler(svc)
+	h.SetRateLimiter(handler.NewRateLimiter(memorycache.NewRateLimiter(), handler.RateLimitPolicies{
+		BookingPerUserEvent: ports.RateLimit{Limit: 1, Window: time.Minute},
+	}))
+
+	userID := uuid.NewString()
+	eventID := uuid.NewString()
+	book := func() *httptest.ResponseRecorder {
+		body := `{"user_id":"` + userID + `","event_id":"` + eventID + `","seat_ids":["` + uuid.NewString() + `"]}`
+		rec := httptest.NewRecorder()
+		h.LegacyCreateBooking(rec, httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusConflict, book().Code, "the first attempt reaches the service")
+
+	limited := book()
+
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "60", limited.Header().Get("Retry-After"))
+}
+
+func TestRateLimiter_FailsOpen(t *testing.T) {
+	limiter := mocks.NewRateLimiter(t)
+	limiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).Return(ports.RateLimitResult{}, errors.New("connection refused"))
+
+	h := handler.NewBookingHandler(nil)
+	h.SetRateLimiter(handler.NewRateLimiter(limiter, handler.RateLimitPolicies{
+		SeatReadsPerIP: ports.RateLimit{Limit: 1, Window: time.Minute},
+	}))
+
+	rec := httptest.NewRecorder()
+	h.LegacyGetSeats(rec, httptest.NewRequest(http.MethodGet, "/seats", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "request reaches the handler")
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
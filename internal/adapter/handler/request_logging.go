@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLogging gives every request an ID, reusing the caller's X-Request-ID
+// when it is usable, and echoes it in the response. The request context gets
+// a logger carrying request_id, and one access line is logged per request.
+func RequestLogging(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return requestLogging(logger, next)
+	}
+}
+
+func requestLogging(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := logging.RequestID(r.Header.Get(RequestIDHeader))
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := logging.With(logging.WithLogger(r.Context(), logger), "request_id", requestID)
+		routed := r.WithContext(ctx)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, routed)
+
+		// ServeMux recorded the matched route on our copy of the request;
+		// hand it back so middleware outside this one can see it too.
+		r.Pattern = routed.Pattern
+
+		route := routed.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+
+		level := slog.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		logging.FromContext(ctx).Log(ctx, level, "HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"route", route,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+
+	return n, err
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+// apiV1Prefix starts every versioned route. Responses elsewhere, including
+// the deprecated unversioned routes, keep the original format.
+const apiV1Prefix = "/v1/"
+
+func isV1(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, apiV1Prefix)
+}
+
+// envelope wraps every /v1 response body: Data on success, Error otherwise.
+type envelope struct {
+	Data  any        `json:"data,omitempty"`
+	Error *errorBody `json:"error,omitempty"`
+}
+
+type errorBody struct {
+	// Code is the snake_case status text, e.g. "not_found", so clients can
+	// branch without parsing Message.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Fields lists every invalid field when a request fails validation.
+	Fields []services.FieldError `json:"fields,omitempty"`
+}
+
+// writeJSON writes v with status, wrapped as {"data": v} under /v1.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	if isV1(r) {
+		v = envelope{Data: v}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes {"error": {"code": ..., "message": msg}} under /v1 and
+// {"error": msg} on the legacy routes.
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse(isV1(r), status, msg))
+}
+
+func errorResponse(v1 bool, status int, msg string) any {
+	if !v1 {
+		return map[string]string{"error": msg}
+	}
+
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+
+	return envelope{Error: &errorBody{Code: code, Message: msg}}
+}
+
+// writeValidationError answers 400 for a request that failed validation,
+// listing the invalid fields under /v1.
+func writeValidationError(w http.ResponseWriter, r *http.Request, err *services.ValidationError) {
+	if !isV1(r) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(envelope{Error: &errorBody{Code: "bad_request", Message: err.Error(), Fields: err.Fields}})
+}
+
+// writeDecodeError reports a request body that couldn't be decoded, telling
+// an oversized body (see LimitBody) apart from malformed JSON.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+
+	writeError(w, r, http.StatusBadRequest, "invalid json body")
+}
@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// InstrumentGRPCUnary records the duration of every unary call, labelled by
+// full method name and status code.
+func (m *Metrics) InstrumentGRPCUnary(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	m.grpcDuration.
+		WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+// InstrumentGRPCStream counts open streams and records each stream's
+// lifetime when it ends.
+func (m *Metrics) InstrumentGRPCStream(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+	start := time.Now()
+	active := m.grpcStreams.WithLabelValues(info.FullMethod)
+
+	active.Inc()
+	defer active.Dec()
+
+	err := handler(srv, ss)
+
+	m.grpcDuration.
+		WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+
+	return err
+}
@@ -0,0 +1,53 @@
+package prometheus
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// InstrumentHTTP records the duration of every request handled by next. The
+// route label is the ServeMux pattern that matched, so path parameters don't
+// create new series; requests that matched nothing are labelled "unmatched".
+func (m *Metrics) InstrumentHTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		// ServeMux fills in r.Pattern while routing.
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.httpDuration.
+			WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,172 @@
+// Package prometheus implements ports.Metrics with Prometheus collectors and
+// provides the HTTP, gRPC and Redis instrumentation served alongside them at
+// /metrics.
+package prometheus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "ticket"
+
+// Metrics holds every application collector. Create it once per registry.
+type Metrics struct {
+	bookingsCreated *prom.CounterVec
+	bookingsFailed  *prom.CounterVec
+	bookingsExpired *prom.CounterVec
+	bookingDuration *prom.HistogramVec
+	lockConflicts   *prom.CounterVec
+	cacheLookups    *prom.CounterVec
+	cacheEvents     *prom.CounterVec
+	reservations    *prom.CounterVec
+	cleanupBacklog  prom.Gauge
+
+	httpDuration  *prom.HistogramVec
+	grpcDuration  *prom.HistogramVec
+	grpcStreams   *prom.GaugeVec
+	redisDuration *prom.HistogramVec
+}
+
+func NewMetrics(reg prom.Registerer) *Metrics {
+	m := &Metrics{
+		bookingsCreated: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "bookings_created_total",
+			Help:      "Bookings created, by event.",
+		}, []string{"event_id"}),
+		bookingsFailed: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "bookings_failed_total",
+			Help:      "Booking attempts that failed, by event and reason.",
+		}, []string{"event_id", "reason"}),
+		bookingsExpired: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "bookings_expired_total",
+			Help:      "Pending bookings released by the cleanup worker, by reason.",
+		}, []string{"reason"}),
+		bookingDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "booking_duration_seconds",
+			Help:      "Time spent creating a booking, by outcome.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"outcome"}),
+		lockConflicts: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "seat_lock_conflicts_total",
+			Help:      "Seat locks lost to a concurrent booking, by event.",
+		}, []string{"event_id"}),
+		cacheLookups: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "seat_cache_lookups_total",
+			Help:      "Available-seat reads, by whether the seat cache served them.",
+		}, []string{"result"}),
+		cacheEvents: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "seat_cache_events_total",
+			Help:      "Seat cache rebuild activity, such as coalesced misses and stale rebuilds, by event.",
+		}, []string{"event"}),
+		reservations: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservation_events_total",
+			Help:      "Redis reservation claims, writes and reconciler activity, by event.",
+		}, []string{"event"}),
+		cleanupBacklog: prom.NewGauge(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cleanup_backlog",
+			Help:      "Expired bookings still pending at the start of the last cleanup run.",
+		}),
+		httpDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request duration, by route pattern, method and status code.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		grpcDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC call duration, by method and status code. Streams are observed when they end.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"method", "code"}),
+		grpcStreams: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "grpc_active_streams",
+			Help:      "Open gRPC server streams, by method.",
+		}, []string{"method"}),
+		redisDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "redis_command_duration_seconds",
+			Help:      "Redis command latency, by command.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"command"}),
+	}
+
+	reg.MustRegister(
+		m.bookingsCreated,
+		m.bookingsFailed,
+		m.bookingsExpired,
+		m.bookingDuration,
+		m.lockConflicts,
+		m.cacheLookups,
+		m.cacheEvents,
+		m.reservations,
+		m.cleanupBacklog,
+		m.httpDuration,
+		m.grpcDuration,
+		m.grpcStreams,
+		m.redisDuration,
+	)
+
+	return m
+}
+
+func eventLabel(eventID uuid.UUID) string {
+	if eventID == uuid.Nil {
+		return "unknown"
+	}
+
+	return eventID.String()
+}
+
+func (m *Metrics) BookingCreated(eventID uuid.UUID) {
+	m.bookingsCreated.WithLabelValues(eventLabel(eventID)).Inc()
+}
+
+func (m *Metrics) BookingFailed(eventID uuid.UUID, reason string) {
+	m.bookingsFailed.WithLabelValues(eventLabel(eventID), reason).Inc()
+}
+
+func (m *Metrics) BookingExpired(reason string) {
+	m.bookingsExpired.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) BookingDuration(outcome string, elapsed time.Duration) {
+	m.bookingDuration.WithLabelValues(outcome).Observe(elapsed.Seconds())
+}
+
+func (m *Metrics) LockConflict(eventID uuid.UUID) {
+	m.lockConflicts.WithLabelValues(eventLabel(eventID)).Inc()
+}
+
+func (m *Metrics) SeatCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cacheLookups.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) SeatCacheEvent(event string) {
+	m.cacheEvents.WithLabelValues(event).Inc()
+}
+
+func (m *Metrics) ReservationEvent(event string) {
+	m.reservations.WithLabelValues(event).Inc()
+}
+
+func (m *Metrics) CleanupBacklog(size int) {
+	m.cleanupBacklog.Set(float64(size))
+}
@@ -0,0 +1,117 @@
+package prometheus_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/metrics/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gather flattens the registry into "name label=value ..." keys. Counters and
+// gauges map to their value, histograms to their sample count.
+func gather(t *testing.T, reg *prom.Registry) map[string]float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			parts := []string{family.GetName()}
+			for _, label := range m.GetLabel() {
+				parts = append(parts, label.GetName()+"="+label.GetValue())
+			}
+
+			sort.Strings(parts[1:])
+			key := strings.Join(parts, " ")
+
+			switch {
+			case m.Counter != nil:
+				values[key] = m.GetCounter().GetValue()
+			case m.Gauge != nil:
+				values[key] = m.GetGauge().GetValue()
+			case m.Histogram != nil:
+				values[key] = float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	return values
+}
+
+func TestMetrics_BookingCounters(t *testing.T) {
+	reg := prom.NewRegistry()
+	metrics := prometheus.NewMetrics(reg)
+	eventID := uuid.New()
+
+	metrics.BookingCreated(eventID)
+	metrics.BookingFailed(eventID, "lock_conflict")
+	metrics.BookingFailed(uuid.Nil, "invalid_request")
+	metrics.SeatCacheLookup(true)
+	metrics.SeatCacheLookup(false)
+	metrics.SeatCacheLookup(false)
+	metrics.SeatCacheEvent("coalesced")
+	metrics.ReservationEvent("reserved")
+	metrics.ReservationEvent("reserved")
+	metrics.CleanupBacklog(42)
+
+	values := gather(t, reg)
+
+	assert.Equal(t, 1.0, values["ticket_bookings_created_total event_id="+eventID.String()])
+	assert.Equal(t, 1.0, values["ticket_bookings_failed_total event_id="+eventID.String()+" reason=lock_conflict"])
+	assert.Equal(t, 1.0, values["ticket_bookings_failed_total event_id=unknown reason=invalid_request"])
+	assert.Equal(t, 1.0, values["ticket_seat_cache_lookups_total result=hit"])
+	assert.Equal(t, 2.0, values["ticket_seat_cache_lookups_total result=miss"])
+	assert.Equal(t, 1.0, values["ticket_seat_cache_events_total event=coalesced"])
+	assert.Equal(t, 2.0, values["ticket_reservation_events_total event=reserved"])
+	assert.Equal(t, 42.0, values["ticket_cleanup_backlog"])
+}
+
+func TestInstrumentHTTP_LabelsByRoutePattern(t *testing.T) {
+	reg := prom.NewRegistry()
+	metrics := prometheus.NewMetrics(reg)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bookings/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	handler := metrics.InstrumentHTTP(mux)
+	for _, path := range []string{"/bookings/1", "/bookings/2", "/nowhere"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	values := gather(t, reg)
+
+	assert.Equal(t, 2.0, values["ticket_http_request_duration_seconds method=GET route=GET /bookings/{id} status=404"])
+	assert.Equal(t, 1.0, values["ticket_http_request_duration_seconds method=GET route=unmatched status=404"])
+}
+
+func TestInstrumentGRPCUnary_LabelsByMethodAndCode(t *testing.T) {
+	reg := prom.NewRegistry()
+	metrics := prometheus.NewMetrics(reg)
+
+	info := &gogrpc.UnaryServerInfo{FullMethod: "/ticket.v1.TicketService/GetBooking"}
+	notFound := func(context.Context, any) (any, error) { return nil, status.Error(codes.NotFound, "booking not found") }
+	found := func(context.Context, any) (any, error) { return "booking", nil }
+
+	metrics.InstrumentGRPCUnary(context.Background(), nil, info, notFound)
+	metrics.InstrumentGRPCUnary(context.Background(), nil, info, found)
+
+	values := gather(t, reg)
+
+	assert.Equal(t, 1.0, values["ticket_grpc_request_duration_seconds code=NotFound method=/ticket.v1.TicketService/GetBooking"])
+	assert.Equal(t, 1.0, values["ticket_grpc_request_duration_seconds code=OK method=/ticket.v1.TicketService/GetBooking"])
+}
@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"context"
+	"net"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisHook returns a go-redis hook that records command latency. Pipelines
+// are recorded once under the command "pipeline".
+func (m *Metrics) RedisHook() goredis.Hook {
+	return redisHook{duration: m.redisDuration}
+}
+
+type redisHook struct {
+	duration *prom.HistogramVec
+}
+
+func (h redisHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		start := time.Now()
+		conn, err := next(ctx, network, addr)
+		h.duration.WithLabelValues("dial").Observe(time.Since(start).Seconds())
+
+		return conn, err
+	}
+}
+
+func (h redisHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.duration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+func (h redisHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.duration.WithLabelValues("pipeline").Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+const expiredBookingsBatch = 100
+
+type BookingRepository struct {
+	store *Store
+}
+
+func NewBookingRepository(store *Store) *BookingRepository {
+	return &BookingRepository{store: store}
+}
+
+func (r *BookingRepository) CreateBooking(ctx context.Context, booking *domain.Booking) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.bookings[booking.ID]; exists {
+		return errors.New("failed to insert booking header: duplicate booking id")
+	}
+
+	if _, exists := r.store.events[booking.EventID]; !exists {
+		return errors.New("failed to insert booking header: event does not exist")
+	}
+
+	items := make([]domain.BookingItem, 0, len(booking.Items))
+	for _, item := range booking.Items {
+		if _, exists := r.store.seats[item.SeatID]; !exists {
+			return fmt.Errorf("failed to insert booking item seat %s: seat does not exist", item.SeatID)
+		}
+
+		item.Seat = nil
+		items = append(items, item)
+	}
+
+	header := *booking
+	header.Items = nil
+	header.Payment = nil
+
+	r.store.bookings[booking.ID] = header
+	r.store.items[booking.ID] = items
+
+	return nil
+}
+
+func (r *BookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUID, status domain.BookingStatus) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	booking, ok := r.store.bookings[bookingID]
+	if !ok {
+		return errors.New("booking not found")
+	}
+
+	booking.Status = status
+	booking.ConfirmedAt = nil
+	if status == domain.BookingConfirmed {
+		now := time.Now()
+		booking.ConfirmedAt = &now
+	}
+
+	r.store.bookings[bookingID] = booking
+
+	userID := booking.UserID
+
+	switch status {
+	case domain.BookingConfirmed:
+		r.transitionBookingSeats(bookingID, domain.SeatBooked, &userID, domain.AuditReasonConfirmed)
+	case domain.BookingCancelled:
+		r.transitionBookingSeats(bookingID, domain.SeatAvailable, &userID, domain.AuditReasonCancelled)
+	case domain.BookingExpired:
+		r.transitionBookingSeats(bookingID, domain.SeatAvailable, nil, domain.AuditReasonExpired)
+	}
+
+	return nil
+}
+
+func (r *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUID, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+
+	var expired []domain.Booking
+	for _, booking := range r.store.bookings {
+		if booking.Status == domain.BookingPending && booking.ExpiresAt.Before(now) {
+			expired = append(expired, booking)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpiresAt.Before(expired[j].ExpiresAt) })
+
+	if len(expired) > expiredBookingsBatch {
+		expired = expired[:expiredBookingsBatch]
+	}
+
+	var ids []uuid.UUID
+	for _, booking := range expired {
+		ids = append(ids, booking.ID)
+	}
+
+	return ids, nil
+}
+
+func (r *BookingRepository) CountExpiredBookings(ctx context.Context) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+
+	count := 0
+	for _, booking := range r.store.bookings {
+		if booking.Status == domain.BookingPending && booking.ExpiresAt.Before(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *BookingRepository) CancelBooking(ctx context.Context, bookingID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	booking, ok := r.store.bookings[bookingID]
+	if !ok || booking.Status != domain.BookingPending {
+		return fmt.Errorf("booking %s: %w", bookingID, ports.ErrBookingNotPending)
+	}
+
+	booking.Status = domain.BookingExpired
+	r.store.bookings[bookingID] = booking
+
+	r.transitionBookingSeats(bookingID, domain.SeatAvailable, nil, domain.AuditReasonExpired)
+
+	return nil
+}
+
+func (r *BookingRepository) GetByID(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.store.bookings[bookingID]; !ok {
+		return nil, errors.New("booking not found")
+	}
+
+	booking := r.loadBooking(bookingID)
+	return &booking, nil
+}
+
+func (r *BookingRepository) ListByUser(ctx context.Context, filter domain.BookingFilter) ([]domain.Booking, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matched []domain.Booking
+	for _, booking := range r.store.bookings {
+		if booking.UserID != filter.UserID {
+			continue
+		}
+
+		if filter.Status != nil && booking.Status != *filter.Status {
+			continue
+		}
+
+		if filter.EventID != nil && booking.EventID != *filter.EventID {
+			continue
+		}
+
+		if filter.After != nil && !bookingBefore(booking, filter.After.CreatedAt, filter.After.ID) {
+			continue
+		}
+
+		matched = append(matched, booking)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return bookingBefore(matched[j], matched[i].CreatedAt, matched[i].ID)
+	})
+
+	if len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+
+	bookings := make([]domain.Booking, 0, len(matched))
+	for _, booking := range matched {
+		bookings = append(bookings, r.loadBooking(booking.ID))
+	}
+
+	return bookings, nil
+}
+
+// bookingBefore reports whether (b.CreatedAt, b.ID) < (createdAt, id), the
+// row comparison used for keyset pagination.
+func bookingBefore(b domain.Booking, createdAt time.Time, id uuid.UUID) bool {
+	if !b.CreatedAt.Equal(createdAt) {
+		return b.CreatedAt.Before(createdAt)
+	}
+
+	return uuidLess(b.ID, id)
+}
+
+// loadBooking assembles a booking with items, seat details and its latest
+// payment. It must be called with the store lock held.
+func (r *BookingRepository) loadBooking(bookingID uuid.UUID) domain.Booking {
+	booking := r.store.bookings[bookingID]
+	booking.Payment = r.store.latestPayment(bookingID)
+
+	stored := r.store.items[bookingID]
+	seats := make([]domain.Seat, 0, len(stored))
+	bySeat := make(map[uuid.UUID]domain.BookingItem, len(stored))
+
+	for _, item := range stored {
+		seat := r.store.seats[item.SeatID]
+		seat.LockedByBookingID = nil
+		seat.LockedAt = nil
+		seats = append(seats, seat)
+		bySeat[item.SeatID] = item
+	}
+
+	sortSeatsLikePostgres(seats)
+
+	booking.Items = make([]domain.BookingItem, 0, len(seats))
+	for _, seat := range seats {
+		seat := seat
+		item := bySeat[seat.ID]
+		item.Seat = &seat
+		booking.Items = append(booking.Items, item)
+	}
+
+	return booking
+}
+
+// transitionBookingSeats mirrors the Postgres helper of the same name. It
+// must be called with the store lock held for writing.
+func (r *BookingRepository) transitionBookingSeats(bookingID uuid.UUID, newStatus domain.SeatStatus, actorID *uuid.UUID, reason string) {
+	var ids []uuid.UUID
+	for id, seat := range r.store.seats {
+		if seat.LockedByBookingID == nil || *seat.LockedByBookingID != bookingID || seat.Status != domain.SeatLocked {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return uuidLess(ids[i], ids[j]) })
+
+	for _, id := range ids {
+		seat := r.store.seats[id]
+		oldStatus := seat.Status
+
+		seat.Status = newStatus
+		seat.Version++
+		if newStatus == domain.SeatAvailable {
+			seat.LockedByBookingID = nil
+			seat.LockedAt = nil
+		}
+
+		r.store.seats[id] = seat
+
+		r.store.writeAudit(domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       oldStatus,
+			NewStatus:       newStatus,
+			ChangedByUserID: actorID,
+			BookingID:       &bookingID,
+			Reason:          reason,
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/repotest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Harness { return newHarness() })
+}
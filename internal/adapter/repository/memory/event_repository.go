@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+type EventRepository struct {
+	store *Store
+}
+
+func NewEventRepository(store *Store) *EventRepository {
+	return &EventRepository{store: store}
+}
+
+func (r *EventRepository) GetByID(ctx context.Context, eventID uuid.UUID) (*domain.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	event, ok := r.store.events[eventID]
+	if !ok {
+		return nil, errors.New("event not found")
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) ListByReservationMode(ctx context.Context, mode domain.ReservationMode) ([]domain.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []domain.Event
+	for _, event := range r.store.events {
+		if event.ReservationMode == mode && event.IsActive {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return uuidLess(events[i].ID, events[j].ID) })
+
+	return events, nil
+}
+
+func (r *EventRepository) SetReservationMode(ctx context.Context, eventID uuid.UUID, mode domain.ReservationMode) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	event, ok := r.store.events[eventID]
+	if !ok {
+		return errors.New("event not found")
+	}
+
+	event.ReservationMode = mode
+	r.store.events[eventID] = event
+
+	return nil
+}
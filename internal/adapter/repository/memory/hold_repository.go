@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+const dueHoldsBatch = 100
+
+type HoldRepository struct {
+	store *Store
+}
+
+func NewHoldRepository(store *Store) *HoldRepository {
+	return &HoldRepository{store: store}
+}
+
+func (r *HoldRepository) CreateHold(ctx context.Context, hold *domain.InventoryHold) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.holds[hold.ID]; exists {
+		return errors.New("failed to insert hold: duplicate hold id")
+	}
+
+	if _, exists := r.store.events[hold.EventID]; !exists {
+		return errors.New("failed to insert hold: event does not exist")
+	}
+
+	for _, id := range hold.SeatIDs {
+		seat, ok := r.store.seats[id]
+		if !ok || seat.EventID != hold.EventID {
+			return fmt.Errorf("seat not found: %s", id)
+		}
+
+		if seat.Status != domain.SeatAvailable {
+			return fmt.Errorf("seat %s is not available", id)
+		}
+	}
+
+	holdID, createdBy := hold.ID, hold.CreatedBy
+	for _, id := range hold.SeatIDs {
+		seat := r.store.seats[id]
+		seat.Status = domain.SeatUnavailable
+		seat.Version++
+		r.store.seats[id] = seat
+
+		r.store.writeAudit(domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatAvailable,
+			NewStatus:       domain.SeatUnavailable,
+			ChangedByUserID: &createdBy,
+			HoldID:          &holdID,
+			Reason:          domain.AuditReasonHeld,
+		})
+	}
+
+	r.store.holds[hold.ID] = copyHold(*hold)
+
+	return nil
+}
+
+func (r *HoldRepository) GetHold(ctx context.Context, holdID uuid.UUID) (*domain.InventoryHold, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	hold, ok := r.store.holds[holdID]
+	if !ok {
+		return nil, errors.New("hold not found")
+	}
+
+	hold = copyHold(hold)
+	return &hold, nil
+}
+
+func (r *HoldRepository) ListHoldsByEvent(ctx context.Context, eventID uuid.UUID, status *domain.HoldStatus) ([]domain.InventoryHold, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var holds []domain.InventoryHold
+	for _, hold := range r.store.holds {
+		if hold.EventID != eventID {
+			continue
+		}
+
+		if status != nil && hold.Status != *status {
+			continue
+		}
+
+		holds = append(holds, copyHold(hold))
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		if !holds[i].CreatedAt.Equal(holds[j].CreatedAt) {
+			return holds[i].CreatedAt.After(holds[j].CreatedAt)
+		}
+
+		return uuidLess(holds[j].ID, holds[i].ID)
+	})
+
+	return holds, nil
+}
+
+func (r *HoldRepository) ReleaseHold(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	hold, err := r.activeHold(holdID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range hold.SeatIDs {
+		seat := r.store.seats[id]
+		if seat.Status != domain.SeatUnavailable {
+			continue
+		}
+
+		seat.Status = domain.SeatAvailable
+		seat.Version++
+		r.store.seats[id] = seat
+
+		r.store.writeAudit(domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatUnavailable,
+			NewStatus:       domain.SeatAvailable,
+			ChangedByUserID: actorID,
+			HoldID:          &holdID,
+			Reason:          reason,
+		})
+	}
+
+	now := time.Now()
+	hold.Status = domain.HoldReleased
+	hold.ClosedBy = copyID(actorID)
+	hold.ClosedAt = &now
+	r.store.holds[holdID] = hold
+
+	return nil
+}
+
+func (r *HoldRepository) ConvertHold(ctx context.Context, holdID uuid.UUID, booking *domain.Booking, actorID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	hold, err := r.activeHold(holdID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range hold.SeatIDs {
+		if r.store.seats[id].Status != domain.SeatUnavailable {
+			return fmt.Errorf("seat %s is not held", id)
+		}
+	}
+
+	if len(booking.Items) != len(hold.SeatIDs) {
+		return errors.New("booking does not match the hold's seats")
+	}
+
+	for _, item := range booking.Items {
+		if !slices.Contains(hold.SeatIDs, item.SeatID) {
+			return errors.New("booking does not match the hold's seats")
+		}
+	}
+
+	if _, exists := r.store.bookings[booking.ID]; exists {
+		return errors.New("failed to insert booking header: duplicate booking id")
+	}
+
+	header := *booking
+	header.Items = nil
+	header.Payment = nil
+	r.store.bookings[booking.ID] = header
+
+	items := make([]domain.BookingItem, 0, len(booking.Items))
+	for _, item := range booking.Items {
+		item.Seat = nil
+		items = append(items, item)
+	}
+
+	r.store.items[booking.ID] = items
+
+	if booking.Payment != nil {
+		payment := *booking.Payment
+		payment.BookingID = booking.ID
+		r.store.payments[booking.ID] = append(r.store.payments[booking.ID], payment)
+	}
+
+	bookingID := booking.ID
+	now := time.Now()
+	for _, id := range hold.SeatIDs {
+		seat := r.store.seats[id]
+		seat.Status = domain.SeatBooked
+		seat.LockedByBookingID = &bookingID
+		seat.LockedAt = &now
+		seat.Version++
+		r.store.seats[id] = copySeat(seat)
+
+		r.store.writeAudit(domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatUnavailable,
+			NewStatus:       domain.SeatBooked,
+			ChangedByUserID: &actorID,
+			BookingID:       &bookingID,
+			HoldID:          &holdID,
+			Reason:          domain.AuditReasonComplimentary,
+		})
+	}
+
+	hold.Status = domain.HoldConverted
+	hold.ClosedBy = &actorID
+	hold.ClosedAt = &now
+	hold.BookingID = &bookingID
+	r.store.holds[holdID] = hold
+
+	return nil
+}
+
+func (r *HoldRepository) GetDueHolds(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var due []domain.InventoryHold
+	for _, hold := range r.store.holds {
+		if hold.IsDue(now) {
+			due = append(due, hold)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].ReleaseAt.Before(*due[j].ReleaseAt) })
+
+	if len(due) > dueHoldsBatch {
+		due = due[:dueHoldsBatch]
+	}
+
+	var ids []uuid.UUID
+	for _, hold := range due {
+		ids = append(ids, hold.ID)
+	}
+
+	return ids, nil
+}
+
+// activeHold must be called with the store lock held.
+func (r *HoldRepository) activeHold(holdID uuid.UUID) (domain.InventoryHold, error) {
+	hold, ok := r.store.holds[holdID]
+	if !ok {
+		return domain.InventoryHold{}, errors.New("hold not found")
+	}
+
+	if hold.Status != domain.HoldActive {
+		return domain.InventoryHold{}, ports.ErrHoldNotActive
+	}
+
+	return hold, nil
+}
+
+// copyHold returns a hold that shares no pointers or slices with h. Seat IDs
+// are sorted the way Postgres orders UUIDs.
+func copyHold(h domain.InventoryHold) domain.InventoryHold {
+	h.SeatIDs = slices.Clone(h.SeatIDs)
+	sort.Slice(h.SeatIDs, func(i, j int) bool { return uuidLess(h.SeatIDs[i], h.SeatIDs[j]) })
+
+	if h.ReleaseAt != nil {
+		at := *h.ReleaseAt
+		h.ReleaseAt = &at
+	}
+
+	if h.ClosedAt != nil {
+		at := *h.ClosedAt
+		h.ClosedAt = &at
+	}
+
+	h.ClosedBy = copyID(h.ClosedBy)
+	h.BookingID = copyID(h.BookingID)
+
+	return h
+}
+
+func copyID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+
+	c := *id
+	return &c
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+type SeatAuditRepository struct {
+	store *Store
+}
+
+func NewSeatAuditRepository(store *Store) *SeatAuditRepository {
+	return &SeatAuditRepository{store: store}
+}
+
+func (r *SeatAuditRepository) ListBySeat(ctx context.Context, seatID uuid.UUID, limit int) ([]domain.SeatAuditLog, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// Walk newest-first so the stable sort keeps later writes first when two
+	// entries share a timestamp.
+	var logs []domain.SeatAuditLog
+	for i := len(r.store.audit) - 1; i >= 0; i-- {
+		if r.store.audit[i].SeatID == seatID {
+			logs = append(logs, r.store.audit[i])
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].ChangedAt.After(logs[j].ChangedAt) })
+
+	if len(logs) > limit {
+		logs = logs[:limit]
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+type SeatRepository struct {
+	store *Store
+}
+
+func NewSeatRepository(store *Store) *SeatRepository {
+	return &SeatRepository{store: store}
+}
+
+func (r *SeatRepository) GetByID(ctx context.Context, seatID uuid.UUID) (*domain.Seat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seat, ok := r.store.seats[seatID]
+	if !ok {
+		return nil, ports.ErrSeatNotFound
+	}
+
+	seat = copySeat(seat)
+	return &seat, nil
+}
+
+func (r *SeatRepository) GetAvailableSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	return r.listByEvent(eventID, func(seat domain.Seat) bool { return seat.Status == domain.SeatAvailable }), nil
+}
+
+func (r *SeatRepository) GetSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	return r.listByEvent(eventID, func(seat domain.Seat) bool { return true }), nil
+}
+
+// listByEvent returns the same columns the Postgres queries select, so the
+// lock fields are left empty.
+func (r *SeatRepository) listByEvent(eventID uuid.UUID, keep func(domain.Seat) bool) []domain.Seat {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var seats []domain.Seat
+	for _, seat := range r.store.seats {
+		if seat.EventID != eventID || !keep(seat) {
+			continue
+		}
+
+		seat.LockedByBookingID = nil
+		seat.LockedAt = nil
+		seats = append(seats, seat)
+	}
+
+	sortSeatsLikePostgres(seats)
+	return seats
+}
+
+func (r *SeatRepository) GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.PricingTier, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tiers []domain.PricingTier
+	for _, tier := range r.store.tiers {
+		if tier.EventID == eventID {
+			tiers = append(tiers, tier)
+		}
+	}
+
+	sort.Slice(tiers, func(i, j int) bool {
+		if tiers[i].Price != tiers[j].Price {
+			return tiers[i].Price > tiers[j].Price
+		}
+
+		return tiers[i].Name < tiers[j].Name
+	})
+
+	return tiers, nil
+}
+
+func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID, currentVersion int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	seat, ok := r.store.seats[seatID]
+	if !ok || seat.Version != currentVersion || seat.Status != domain.SeatAvailable {
+		logging.FromContext(ctx).DebugContext(ctx, "Optimistic seat lock lost", "seat_id", seatID, "version", currentVersion)
+		return errors.New("optimistic lock failed: seat was modified by another transaction")
+	}
+
+	now := time.Now()
+	seat.Status = domain.SeatLocked
+	seat.LockedByBookingID = &bookingID
+	seat.LockedAt = &now
+	seat.Version++
+	r.store.seats[seatID] = seat
+
+	r.store.writeAudit(domain.SeatAuditLog{
+		SeatID:          seatID,
+		OldStatus:       domain.SeatAvailable,
+		NewStatus:       domain.SeatLocked,
+		ChangedByUserID: &actorID,
+		BookingID:       &bookingID,
+		Reason:          domain.AuditReasonSeatLocked,
+	})
+
+	return nil
+}
+
+func (r *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	seat, ok := r.store.seats[seatID]
+	if !ok {
+		return ports.ErrSeatNotFound
+	}
+
+	if seat.Status != domain.SeatLocked || seat.LockedByBookingID == nil || *seat.LockedByBookingID != bookingID {
+		return errors.New("seat is not locked by this booking")
+	}
+
+	entry := domain.SeatAuditLog{
+		SeatID:          seatID,
+		OldStatus:       domain.SeatLocked,
+		NewStatus:       domain.SeatAvailable,
+		ChangedByUserID: &actorID,
+		BookingID:       &bookingID,
+		Reason:          domain.AuditReasonLockRolledBack,
+	}
+
+	seat.Status = domain.SeatAvailable
+	seat.LockedByBookingID = nil
+	seat.LockedAt = nil
+	seat.Version++
+	r.store.seats[seatID] = seat
+
+	r.store.writeAudit(entry)
+
+	return nil
+}
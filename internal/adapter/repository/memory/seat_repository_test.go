@@ -0,0 +1,99 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedSeat(t *testing.T, store *memory.Store) (eventID, seatID uuid.UUID) {
+	t.Helper()
+
+	eventID, seatID = uuid.New(), uuid.New()
+	require.NoError(t, store.AddEvent(domain.Event{ID: eventID, Name: "Concert", IsActive: true}))
+	require.NoError(t, store.AddSeat(domain.Seat{ID: seatID, EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "1"}))
+
+	return eventID, seatID
+}
+
+func TestSeatRepository_LockSeatHasOneWinner(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewSeatRepository(store)
+	_, seatID := seedSeat(t, store)
+
+	const callers = 20
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		wins int
+	)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := repo.LockSeat(context.Background(), seatID, uuid.New(), uuid.New(), 1); err == nil {
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, wins)
+
+	seat, err := repo.GetByID(context.Background(), seatID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeatLocked, seat.Status)
+	assert.Equal(t, 2, seat.Version)
+}
+
+func TestBookingRepository_ConfirmBooksLockedSeats(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	seats := memory.NewSeatRepository(store)
+	bookings := memory.NewBookingRepository(store)
+	audit := memory.NewSeatAuditRepository(store)
+	eventID, seatID := seedSeat(t, store)
+
+	userID, bookingID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	require.NoError(t, seats.LockSeat(ctx, seatID, bookingID, userID, 1))
+	require.NoError(t, bookings.CreateBooking(ctx, &domain.Booking{
+		ID:        bookingID,
+		UserID:    userID,
+		EventID:   eventID,
+		Status:    domain.BookingPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(10 * time.Minute),
+		Items:     []domain.BookingItem{{ID: uuid.New(), BookingID: bookingID, SeatID: seatID, PriceAtBooking: 100}},
+	}))
+
+	require.NoError(t, bookings.UpdateStatus(ctx, bookingID, domain.BookingConfirmed))
+
+	booking, err := bookings.GetByID(ctx, bookingID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BookingConfirmed, booking.Status)
+	assert.NotNil(t, booking.ConfirmedAt)
+	require.Len(t, booking.Items, 1)
+	assert.Equal(t, domain.SeatBooked, booking.Items[0].Seat.Status)
+
+	history, err := audit.ListBySeat(ctx, seatID, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, domain.AuditReasonConfirmed, history[0].Reason)
+	assert.Equal(t, domain.AuditReasonSeatLocked, history[1].Reason)
+
+	assert.EqualError(t, bookings.UpdateStatus(ctx, uuid.New(), domain.BookingConfirmed), "booking not found")
+}
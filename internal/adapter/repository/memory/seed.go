@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+// SeedDemo loads the same sample event, tier and seats as the Postgres seed
+// step, so a memory-backed server can be exercised with the IDs from the
+// README.
+func SeedDemo(store *Store) error {
+	eventID := uuid.MustParse("b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22")
+	tierID := uuid.MustParse("f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99")
+	start := time.Now().AddDate(0, 1, 0)
+
+	if err := store.AddEvent(domain.Event{
+		ID:        eventID,
+		VenueID:   uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"),
+		Name:      "Coldplay Jakarta",
+		StartTime: start,
+		EndTime:   start.Add(4 * time.Hour),
+		IsActive:  true,
+	}); err != nil {
+		return err
+	}
+
+	if err := store.AddTier(domain.PricingTier{ID: tierID, EventID: eventID, Name: "VIP", Price: 5000000}); err != nil {
+		return err
+	}
+
+	seats := []domain.Seat{
+		{ID: uuid.MustParse("c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a01"), SeatNumber: "1"},
+		{ID: uuid.MustParse("c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a02"), SeatNumber: "2"},
+	}
+
+	for _, seat := range seats {
+		seat.EventID = eventID
+		seat.TierID = tierID
+		seat.Section = "A"
+		seat.RowNumber = "1"
+
+		if err := store.AddSeat(seat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
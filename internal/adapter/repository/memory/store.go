@@ -0,0 +1,184 @@
+// Package memory implements the repository ports in process memory. It copies
+// the semantics of the Postgres adapter (optimistic version checks, status
+// guards, foreign keys, ordering and limits) so it can stand in for Postgres
+// in tests and local development. All repositories created from one Store
+// share its data and its lock, which plays the role of a transaction.
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+type Store struct {
+	mu sync.RWMutex
+
+	events   map[uuid.UUID]domain.Event
+	tiers    map[uuid.UUID]domain.PricingTier
+	seats    map[uuid.UUID]domain.Seat
+	bookings map[uuid.UUID]domain.Booking
+	items    map[uuid.UUID][]domain.BookingItem
+	payments map[uuid.UUID][]domain.Payment
+	holds    map[uuid.UUID]domain.InventoryHold
+	audit    []domain.SeatAuditLog
+}
+
+func NewStore() *Store {
+	return &Store{
+		events:   make(map[uuid.UUID]domain.Event),
+		tiers:    make(map[uuid.UUID]domain.PricingTier),
+		seats:    make(map[uuid.UUID]domain.Seat),
+		bookings: make(map[uuid.UUID]domain.Booking),
+		items:    make(map[uuid.UUID][]domain.BookingItem),
+		payments: make(map[uuid.UUID][]domain.Payment),
+		holds:    make(map[uuid.UUID]domain.InventoryHold),
+	}
+}
+
+// AddEvent inserts an event. An empty reservation mode defaults to DATABASE,
+// as the column default does.
+func (s *Store) AddEvent(event domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.events[event.ID]; exists {
+		return errors.New("duplicate event id")
+	}
+
+	if event.ReservationMode == "" {
+		event.ReservationMode = domain.ReservationDatabase
+	}
+
+	s.events[event.ID] = event
+	return nil
+}
+
+func (s *Store) AddTier(tier domain.PricingTier) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.events[tier.EventID]; !exists {
+		return errors.New("pricing tier references unknown event")
+	}
+
+	s.tiers[tier.ID] = tier
+	return nil
+}
+
+// AddSeat inserts a seat. Status and version default to AVAILABLE and 1.
+func (s *Store) AddSeat(seat domain.Seat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.seats[seat.ID]; exists {
+		return errors.New("duplicate seat id")
+	}
+
+	if _, exists := s.events[seat.EventID]; !exists {
+		return errors.New("seat references unknown event")
+	}
+
+	if _, exists := s.tiers[seat.TierID]; seat.TierID != uuid.Nil && !exists {
+		return errors.New("seat references unknown pricing tier")
+	}
+
+	for _, other := range s.seats {
+		if other.EventID == seat.EventID && other.Section == seat.Section &&
+			other.RowNumber == seat.RowNumber && other.SeatNumber == seat.SeatNumber {
+			return errors.New("duplicate seat per event")
+		}
+	}
+
+	if seat.Status == "" {
+		seat.Status = domain.SeatAvailable
+	}
+
+	if seat.Version == 0 {
+		seat.Version = 1
+	}
+
+	s.seats[seat.ID] = seat
+	return nil
+}
+
+func (s *Store) AddPayment(payment domain.Payment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.bookings[payment.BookingID]; !exists {
+		return errors.New("payment references unknown booking")
+	}
+
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = time.Now()
+	}
+
+	s.payments[payment.BookingID] = append(s.payments[payment.BookingID], payment)
+	return nil
+}
+
+// writeAudit must be called with s.mu held for writing.
+func (s *Store) writeAudit(entry domain.SeatAuditLog) {
+	entry.ID = uuid.New()
+	entry.ChangedAt = time.Now()
+	s.audit = append(s.audit, entry)
+}
+
+// latestPayment must be called with s.mu held.
+func (s *Store) latestPayment(bookingID uuid.UUID) *domain.Payment {
+	payments := s.payments[bookingID]
+	if len(payments) == 0 {
+		return nil
+	}
+
+	latest := payments[0]
+	for _, p := range payments[1:] {
+		if p.PaidAt.After(latest.PaidAt) {
+			latest = p
+		}
+	}
+
+	return &latest
+}
+
+func copySeat(seat domain.Seat) domain.Seat {
+	if seat.LockedByBookingID != nil {
+		id := *seat.LockedByBookingID
+		seat.LockedByBookingID = &id
+	}
+
+	if seat.LockedAt != nil {
+		at := *seat.LockedAt
+		seat.LockedAt = &at
+	}
+
+	return seat
+}
+
+// sortSeatsLikePostgres orders seats by section, row and seat number as plain
+// text, which is what ORDER BY does on the VARCHAR columns.
+func sortSeatsLikePostgres(seats []domain.Seat) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		a, b := seats[i], seats[j]
+		if a.Section != b.Section {
+			return a.Section < b.Section
+		}
+
+		if a.RowNumber != b.RowNumber {
+			return a.RowNumber < b.RowNumber
+		}
+
+		return a.SeatNumber < b.SeatNumber
+	})
+}
+
+// uuidLess compares UUIDs the way Postgres does: byte by byte.
+func uuidLess(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
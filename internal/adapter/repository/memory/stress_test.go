@@ -0,0 +1,39 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/memory"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/repotest"
+)
+
+func newHarness() repotest.Harness {
+	store := memory.NewStore()
+
+	return repotest.Harness{
+		Seats:    memory.NewSeatRepository(store),
+		Bookings: memory.NewBookingRepository(store),
+		Audit:    memory.NewSeatAuditRepository(store),
+		Events:   memory.NewEventRepository(store),
+		Holds:    memory.NewHoldRepository(store),
+		Fixtures: store,
+	}
+}
+
+func TestBookingStress(t *testing.T) {
+	cfg := repotest.StressConfig{Seats: 500, SeatsPerBooking: 3, Requests: 5000, Workers: 64, Seed: 1}
+	if testing.Short() {
+		cfg.Requests = 500
+	}
+
+	report := repotest.Stress(t, newHarness(), cfg)
+	t.Log(report)
+
+	if report.Succeeded == 0 || report.Conflicts == 0 {
+		t.Errorf("expected both bookings and conflicts, got %s", report)
+	}
+}
+
+func BenchmarkCreateBooking(b *testing.B) {
+	repotest.Stress(b, newHarness(), repotest.StressConfig{Seats: 10000, SeatsPerBooking: 2, Requests: b.N, Workers: 64, Seed: 1})
+}
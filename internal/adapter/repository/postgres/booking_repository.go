@@ -3,13 +3,30 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
 )
 
+const bookingSelect = `
+	SELECT b.id, b.user_id, b.event_id, b.total_amount, b.status, b.created_at, b.expires_at, b.confirmed_at,
+		p.id, p.amount, p.payment_method, p.provider_transaction_id, p.status, p.paid_at
+	FROM bookings b
+	LEFT JOIN LATERAL (
+		SELECT id, amount, payment_method, provider_transaction_id, status, paid_at
+		FROM payments
+		WHERE booking_id = b.id
+		ORDER BY paid_at DESC
+		LIMIT 1
+	) p ON TRUE
+	`
+
 type BookingRepository struct {
 	db *sql.DB
 }
@@ -26,12 +43,26 @@ func (r *BookingRepository) CreateBooking(ctx context.Context, booking *domain.B
 
 	defer tx.Rollback()
 
+	if err := insertBooking(ctx, tx, booking); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertBooking writes a booking header and its items inside the caller's
+// transaction.
+func insertBooking(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
 	queryHeader := `
-	INSERT INTO bookings (id, user_id, event_id, total_amount, status, created_at, expires_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	INSERT INTO bookings (id, user_id, event_id, total_amount, status, created_at, expires_at, confirmed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err = tx.ExecContext(ctx, queryHeader, booking.ID, booking.UserID, booking.EventID, booking.TotalAmount, booking.Status, booking.CreatedAt, booking.ExpiresAt)
+	_, err := tx.ExecContext(ctx, queryHeader, booking.ID, booking.UserID, booking.EventID, booking.TotalAmount, booking.Status, booking.CreatedAt, booking.ExpiresAt, booking.ConfirmedAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert booking header: %w", err)
 	}
@@ -55,18 +86,22 @@ func (r *BookingRepository) CreateBooking(ctx context.Context, booking *domain.B
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
 func (r *BookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUID, status domain.BookingStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
 	query := `
 	UPDATE bookings
 	SET status = $1, confirmed_at = $2
-	WHERE id = $2
+	WHERE id = $3
+	RETURNING user_id
 	`
 
 	var confirmedAt *time.Time
@@ -75,12 +110,206 @@ func (r *BookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUI
 		confirmedAt = &now
 	}
 
-	_, err := r.db.ExecContext(ctx, query, status, confirmedAt, bookingID)
+	var userID uuid.UUID
+	err = tx.QueryRowContext(ctx, query, status, confirmedAt, bookingID).Scan(&userID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("booking not found")
+		}
+
 		return err
 	}
 
-	return nil
+	switch status {
+	case domain.BookingConfirmed:
+		err = transitionBookingSeats(ctx, tx, bookingID, domain.SeatBooked, &userID, domain.AuditReasonConfirmed)
+	case domain.BookingCancelled:
+		err = transitionBookingSeats(ctx, tx, bookingID, domain.SeatAvailable, &userID, domain.AuditReasonCancelled)
+	case domain.BookingExpired:
+		err = transitionBookingSeats(ctx, tx, bookingID, domain.SeatAvailable, nil, domain.AuditReasonExpired)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *BookingRepository) GetByID(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	query := bookingSelect + `WHERE b.id = $1`
+
+	booking, err := scanBooking(r.db.QueryRowContext(ctx, query, bookingID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("booking not found")
+		}
+
+		return nil, err
+	}
+
+	bookings := []domain.Booking{*booking}
+	if err := r.loadItems(ctx, bookings); err != nil {
+		return nil, err
+	}
+
+	return &bookings[0], nil
+}
+
+func (r *BookingRepository) ListByUser(ctx context.Context, filter domain.BookingFilter) ([]domain.Booking, error) {
+	query := bookingSelect + `
+	WHERE b.user_id = $1
+		AND ($2::booking_status IS NULL OR b.status = $2)
+		AND ($3::uuid IS NULL OR b.event_id = $3)
+		AND ($4::timestamptz IS NULL OR (b.created_at, b.id) < ($4, $5))
+	ORDER BY b.created_at DESC, b.id DESC
+	LIMIT $6
+	`
+
+	var afterCreatedAt *time.Time
+	var afterID *uuid.UUID
+	if filter.After != nil {
+		afterCreatedAt = &filter.After.CreatedAt
+		afterID = &filter.After.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, filter.UserID, filter.Status, filter.EventID, afterCreatedAt, afterID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bookings []domain.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bookings = append(bookings, *booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(ctx, bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// loadItems fills Items, including seat details, for all bookings with a
+// single query.
+func (r *BookingRepository) loadItems(ctx context.Context, bookings []domain.Booking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+
+	index := make(map[uuid.UUID]int, len(bookings))
+	ids := make([]string, 0, len(bookings))
+	for i, b := range bookings {
+		index[b.ID] = i
+		ids = append(ids, b.ID.String())
+	}
+
+	query := `
+	SELECT bi.id, bi.booking_id, bi.seat_id, bi.price_at_booking,
+		s.event_id, s.tier_id, s.section, s.row_number, s.seat_number, s.status, s.version
+	FROM booking_items bi
+	JOIN event_seats s ON s.id = bi.seat_id
+	WHERE bi.booking_id = ANY($1::uuid[])
+	ORDER BY s.section, s.row_number, s.seat_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to load booking items: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.BookingItem
+		var seat domain.Seat
+
+		if err := rows.Scan(
+			&item.ID,
+			&item.BookingID,
+			&item.SeatID,
+			&item.PriceAtBooking,
+			&seat.EventID,
+			&seat.TierID,
+			&seat.Section,
+			&seat.RowNumber,
+			&seat.SeatNumber,
+			&seat.Status,
+			&seat.Version,
+		); err != nil {
+			return err
+		}
+
+		seat.ID = item.SeatID
+		item.Seat = &seat
+
+		i := index[item.BookingID]
+		bookings[i].Items = append(bookings[i].Items, item)
+	}
+
+	return rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBooking(row rowScanner) (*domain.Booking, error) {
+	var booking domain.Booking
+	var confirmedAt sql.NullTime
+	var paymentID uuid.NullUUID
+	var paymentAmount sql.NullFloat64
+	var paymentMethod, providerTxID, paymentStatus sql.NullString
+	var paidAt sql.NullTime
+
+	err := row.Scan(
+		&booking.ID,
+		&booking.UserID,
+		&booking.EventID,
+		&booking.TotalAmount,
+		&booking.Status,
+		&booking.CreatedAt,
+		&booking.ExpiresAt,
+		&confirmedAt,
+		&paymentID,
+		&paymentAmount,
+		&paymentMethod,
+		&providerTxID,
+		&paymentStatus,
+		&paidAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		booking.ConfirmedAt = &confirmedAt.Time
+	}
+
+	if paymentID.Valid {
+		booking.Payment = &domain.Payment{
+			ID:                    paymentID.UUID,
+			BookingID:             booking.ID,
+			Amount:                paymentAmount.Float64,
+			PaymentMethod:         paymentMethod.String,
+			ProviderTransactionID: providerTxID.String,
+			Status:                paymentStatus.String,
+			PaidAt:                paidAt.Time,
+		}
+	}
+
+	return &booking, nil
 }
 
 func (r *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUID, error) {
@@ -110,6 +339,20 @@ func (r *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUID
 	return ids, nil
 }
 
+func (r *BookingRepository) CountExpiredBookings(ctx context.Context) (int, error) {
+	query := `
+	SELECT count(*) FROM bookings
+	WHERE status = 'PENDING' AND expires_at < NOW()
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *BookingRepository) CancelBooking(ctx context.Context, bookingID uuid.UUID) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -118,23 +361,93 @@ func (r *BookingRepository) CancelBooking(ctx context.Context, bookingID uuid.UU
 
 	defer tx.Rollback()
 
-	_, err = tx.ExecContext(ctx, `UPDATE bookings SET status = 'EXPIRED' WHERE id = $1`, bookingID)
+	// A payment confirmation can land between the expiry sweep and this
+	// call. The booking is then no longer PENDING and must be left alone.
+	result, err := tx.ExecContext(ctx, `UPDATE bookings SET status = 'EXPIRED' WHERE id = $1 AND status = 'PENDING'`, bookingID)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("booking %s: %w", bookingID, ports.ErrBookingNotPending)
+	}
+
+	if err := transitionBookingSeats(ctx, tx, bookingID, domain.SeatAvailable, nil, domain.AuditReasonExpired); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// transitionBookingSeats moves the seats a booking still has LOCKED to
+// newStatus and audits each seat. Moving to AVAILABLE releases the seat;
+// moving to BOOKED keeps the booking link. BOOKED seats are never released.
+func transitionBookingSeats(ctx context.Context, tx *sql.Tx, bookingID uuid.UUID, newStatus domain.SeatStatus, actorID *uuid.UUID, reason string) error {
+	selectQuery := `SELECT id, status FROM event_seats WHERE locked_by_booking_id = $1 AND status = 'LOCKED' FOR UPDATE`
+	updateQuery := `
 	UPDATE event_seats
 	SET status = 'AVAILABLE',
 		locked_by_booking_id = NULL,
 		locked_at = NULL,
 		version = version + 1
-	WHERE locked_by_booking_id = $1
-	`, bookingID)
+	WHERE locked_by_booking_id = $1 AND status = 'LOCKED'
+	`
+
+	if newStatus == domain.SeatBooked {
+		updateQuery = `
+		UPDATE event_seats
+		SET status = 'BOOKED',
+			version = version + 1
+		WHERE locked_by_booking_id = $1 AND status = 'LOCKED'
+		`
+	}
 
+	rows, err := tx.QueryContext(ctx, selectQuery, bookingID)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	var seats []domain.Seat
+	for rows.Next() {
+		var seat domain.Seat
+		if err := rows.Scan(&seat.ID, &seat.Status); err != nil {
+			rows.Close()
+			return err
+		}
+
+		seats = append(seats, seat)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, updateQuery, bookingID); err != nil {
+		return err
+	}
+
+	for _, seat := range seats {
+		err := insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+			SeatID:          seat.ID,
+			OldStatus:       seat.Status,
+			NewStatus:       newStatus,
+			ChangedByUserID: actorID,
+			BookingID:       &bookingID,
+			Reason:          reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx = logging.With(ctx, "booking_id", bookingID)
+	logging.FromContext(ctx).DebugContext(ctx, "Booking seats transitioned", "seat_count", len(seats), "status", newStatus)
+
+	return nil
 }
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+type EventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+const eventSelect = `
+	SELECT id, venue_id, name, start_time, end_time, COALESCE(is_active, FALSE), reservation_mode
+	FROM events
+	`
+
+func (r *EventRepository) GetByID(ctx context.Context, eventID uuid.UUID) (*domain.Event, error) {
+	event, err := scanEvent(r.db.QueryRowContext(ctx, eventSelect+`WHERE id = $1`, eventID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("event not found")
+		}
+
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (r *EventRepository) ListByReservationMode(ctx context.Context, mode domain.ReservationMode) ([]domain.Event, error) {
+	rows, err := r.db.QueryContext(ctx, eventSelect+`WHERE reservation_mode = $1 AND is_active`, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var events []domain.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, *event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *EventRepository) SetReservationMode(ctx context.Context, eventID uuid.UUID, mode domain.ReservationMode) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE events SET reservation_mode = $1 WHERE id = $2`, mode, eventID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("event not found")
+	}
+
+	return nil
+}
+
+func scanEvent(row rowScanner) (*domain.Event, error) {
+	var event domain.Event
+	var venueID uuid.NullUUID
+
+	err := row.Scan(
+		&event.ID,
+		&venueID,
+		&event.Name,
+		&event.StartTime,
+		&event.EndTime,
+		&event.IsActive,
+		&event.ReservationMode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.VenueID = venueID.UUID
+
+	return &event, nil
+}
@@ -0,0 +1,506 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+const dueHoldsBatch = 100
+
+const holdSelect = `
+	SELECT id, event_id, name, reason, owner, status, release_at, created_by, created_at, closed_by, closed_at, booking_id
+	FROM inventory_holds
+	`
+
+type HoldRepository struct {
+	db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+func (r *HoldRepository) CreateHold(ctx context.Context, hold *domain.InventoryHold) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// Lock in id order so holds over overlapping seats can't deadlock.
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, event_id, status FROM event_seats
+	WHERE id = ANY($1::uuid[])
+	ORDER BY id
+	FOR UPDATE
+	`, pq.Array(uuidStrings(hold.SeatIDs)))
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]domain.Seat, len(hold.SeatIDs))
+	for rows.Next() {
+		var seat domain.Seat
+		if err := rows.Scan(&seat.ID, &seat.EventID, &seat.Status); err != nil {
+			rows.Close()
+			return err
+		}
+
+		found[seat.ID] = seat
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range hold.SeatIDs {
+		seat, ok := found[id]
+		if !ok || seat.EventID != hold.EventID {
+			return fmt.Errorf("seat not found: %s", id)
+		}
+
+		if seat.Status != domain.SeatAvailable {
+			return fmt.Errorf("seat %s is not available", id)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO inventory_holds (id, event_id, name, reason, owner, status, release_at, created_by, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, hold.ID, hold.EventID, hold.Name, hold.Reason, hold.Owner, hold.Status, hold.ReleaseAt, hold.CreatedBy, hold.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert hold: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO inventory_hold_seats (hold_id, seat_id)
+	SELECT $1, unnest($2::uuid[])
+	`, hold.ID, pq.Array(uuidStrings(hold.SeatIDs)))
+	if err != nil {
+		return fmt.Errorf("failed to insert hold seats: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE event_seats
+	SET status = 'UNAVAILABLE',
+		version = version + 1
+	WHERE id = ANY($1::uuid[])
+	`, pq.Array(uuidStrings(hold.SeatIDs)))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range hold.SeatIDs {
+		err := insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatAvailable,
+			NewStatus:       domain.SeatUnavailable,
+			ChangedByUserID: &hold.CreatedBy,
+			HoldID:          &hold.ID,
+			Reason:          domain.AuditReasonHeld,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *HoldRepository) GetHold(ctx context.Context, holdID uuid.UUID) (*domain.InventoryHold, error) {
+	hold, err := scanHold(r.db.QueryRowContext(ctx, holdSelect+`WHERE id = $1`, holdID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("hold not found")
+		}
+
+		return nil, err
+	}
+
+	holds := []domain.InventoryHold{*hold}
+	if err := r.loadSeats(ctx, holds); err != nil {
+		return nil, err
+	}
+
+	return &holds[0], nil
+}
+
+func (r *HoldRepository) ListHoldsByEvent(ctx context.Context, eventID uuid.UUID, status *domain.HoldStatus) ([]domain.InventoryHold, error) {
+	query := holdSelect + `
+	WHERE event_id = $1
+		AND ($2::text IS NULL OR status = $2)
+	ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var holds []domain.InventoryHold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		holds = append(holds, *hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadSeats(ctx, holds); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *HoldRepository) ReleaseHold(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := lockActiveHold(ctx, tx, holdID); err != nil {
+		return err
+	}
+
+	seats, err := lockHoldSeats(ctx, tx, holdID)
+	if err != nil {
+		return err
+	}
+
+	var released []uuid.UUID
+	for _, seat := range seats {
+		if seat.Status == domain.SeatUnavailable {
+			released = append(released, seat.ID)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE event_seats
+	SET status = 'AVAILABLE',
+		version = version + 1
+	WHERE id = ANY($1::uuid[])
+	`, pq.Array(uuidStrings(released)))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range released {
+		err := insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatUnavailable,
+			NewStatus:       domain.SeatAvailable,
+			ChangedByUserID: actorID,
+			HoldID:          &holdID,
+			Reason:          reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE inventory_holds
+	SET status = 'RELEASED', closed_by = $2, closed_at = NOW()
+	WHERE id = $1
+	`, holdID, actorID)
+	if err != nil {
+		return err
+	}
+
+	ctx = logging.With(ctx, "hold_id", holdID)
+	logging.FromContext(ctx).DebugContext(ctx, "Hold released", "seat_count", len(released))
+
+	return tx.Commit()
+}
+
+func (r *HoldRepository) ConvertHold(ctx context.Context, holdID uuid.UUID, booking *domain.Booking, actorID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := lockActiveHold(ctx, tx, holdID); err != nil {
+		return err
+	}
+
+	seats, err := lockHoldSeats(ctx, tx, holdID)
+	if err != nil {
+		return err
+	}
+
+	if err := matchHoldSeats(seats, booking); err != nil {
+		return err
+	}
+
+	if err := insertBooking(ctx, tx, booking); err != nil {
+		return err
+	}
+
+	if payment := booking.Payment; payment != nil {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO payments (id, booking_id, amount, payment_method, provider_transaction_id, status, paid_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, payment.ID, booking.ID, payment.Amount, payment.PaymentMethod, payment.ProviderTransactionID, payment.Status, payment.PaidAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert payment: %w", err)
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(seats))
+	for _, seat := range seats {
+		ids = append(ids, seat.ID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE event_seats
+	SET status = 'BOOKED',
+		locked_by_booking_id = $2,
+		locked_at = NOW(),
+		version = version + 1
+	WHERE id = ANY($1::uuid[])
+	`, pq.Array(uuidStrings(ids)), booking.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		err := insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+			SeatID:          id,
+			OldStatus:       domain.SeatUnavailable,
+			NewStatus:       domain.SeatBooked,
+			ChangedByUserID: &actorID,
+			BookingID:       &booking.ID,
+			HoldID:          &holdID,
+			Reason:          domain.AuditReasonComplimentary,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE inventory_holds
+	SET status = 'CONVERTED', closed_by = $2, closed_at = NOW(), booking_id = $3
+	WHERE id = $1
+	`, holdID, actorID, booking.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *HoldRepository) GetDueHolds(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	query := `
+	SELECT id FROM inventory_holds
+	WHERE status = 'ACTIVE' AND release_at <= $1
+	ORDER BY release_at
+	LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, dueHoldsBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// loadSeats fills SeatIDs for all holds with a single query.
+func (r *HoldRepository) loadSeats(ctx context.Context, holds []domain.InventoryHold) error {
+	if len(holds) == 0 {
+		return nil
+	}
+
+	index := make(map[uuid.UUID]int, len(holds))
+	ids := make([]uuid.UUID, 0, len(holds))
+	for i, h := range holds {
+		index[h.ID] = i
+		ids = append(ids, h.ID)
+	}
+
+	query := `
+	SELECT hold_id, seat_id FROM inventory_hold_seats
+	WHERE hold_id = ANY($1::uuid[])
+	ORDER BY seat_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return fmt.Errorf("failed to load hold seats: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var holdID, seatID uuid.UUID
+		if err := rows.Scan(&holdID, &seatID); err != nil {
+			return err
+		}
+
+		i := index[holdID]
+		holds[i].SeatIDs = append(holds[i].SeatIDs, seatID)
+	}
+
+	return rows.Err()
+}
+
+// lockActiveHold locks a hold row for the rest of the transaction and checks
+// that it is still ACTIVE.
+func lockActiveHold(ctx context.Context, tx *sql.Tx, holdID uuid.UUID) error {
+	var status domain.HoldStatus
+	err := tx.QueryRowContext(ctx, `SELECT status FROM inventory_holds WHERE id = $1 FOR UPDATE`, holdID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("hold not found")
+		}
+
+		return err
+	}
+
+	if status != domain.HoldActive {
+		return ports.ErrHoldNotActive
+	}
+
+	return nil
+}
+
+// lockHoldSeats locks a hold's seats in id order and returns their status.
+func lockHoldSeats(ctx context.Context, tx *sql.Tx, holdID uuid.UUID) ([]domain.Seat, error) {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT s.id, s.status
+	FROM event_seats s
+	JOIN inventory_hold_seats h ON h.seat_id = s.id
+	WHERE h.hold_id = $1
+	ORDER BY s.id
+	FOR UPDATE OF s
+	`, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var seats []domain.Seat
+	for rows.Next() {
+		var seat domain.Seat
+		if err := rows.Scan(&seat.ID, &seat.Status); err != nil {
+			return nil, err
+		}
+
+		seats = append(seats, seat)
+	}
+
+	return seats, rows.Err()
+}
+
+// matchHoldSeats checks that booking lists exactly the hold's seats and that
+// none of them has left UNAVAILABLE.
+func matchHoldSeats(seats []domain.Seat, booking *domain.Booking) error {
+	held := make(map[uuid.UUID]bool, len(seats))
+	for _, seat := range seats {
+		if seat.Status != domain.SeatUnavailable {
+			return fmt.Errorf("seat %s is not held", seat.ID)
+		}
+
+		held[seat.ID] = true
+	}
+
+	if len(booking.Items) != len(held) {
+		return errors.New("booking does not match the hold's seats")
+	}
+
+	for _, item := range booking.Items {
+		if !held[item.SeatID] {
+			return errors.New("booking does not match the hold's seats")
+		}
+	}
+
+	return nil
+}
+
+func scanHold(row rowScanner) (*domain.InventoryHold, error) {
+	var hold domain.InventoryHold
+	var releaseAt, closedAt sql.NullTime
+	var closedBy, bookingID uuid.NullUUID
+
+	err := row.Scan(
+		&hold.ID,
+		&hold.EventID,
+		&hold.Name,
+		&hold.Reason,
+		&hold.Owner,
+		&hold.Status,
+		&releaseAt,
+		&hold.CreatedBy,
+		&hold.CreatedAt,
+		&closedBy,
+		&closedAt,
+		&bookingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if releaseAt.Valid {
+		hold.ReleaseAt = &releaseAt.Time
+	}
+
+	if closedBy.Valid {
+		hold.ClosedBy = &closedBy.UUID
+	}
+
+	if closedAt.Valid {
+		hold.ClosedAt = &closedAt.Time
+	}
+
+	if bookingID.Valid {
+		hold.BookingID = &bookingID.UUID
+	}
+
+	return &hold, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+
+	return strs
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+type SeatAuditRepository struct {
+	db *sql.DB
+}
+
+func NewSeatAuditRepository(db *sql.DB) *SeatAuditRepository {
+	return &SeatAuditRepository{db: db}
+}
+
+func (r *SeatAuditRepository) ListBySeat(ctx context.Context, seatID uuid.UUID, limit int) ([]domain.SeatAuditLog, error) {
+	query := `
+	SELECT id, seat_id, old_status, new_status, changed_by_user_id, booking_id, hold_id, changed_at, COALESCE(reason, '')
+	FROM seat_audit_logs
+	WHERE seat_id = $1
+	ORDER BY changed_at DESC
+	LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, seatID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var logs []domain.SeatAuditLog
+	for rows.Next() {
+		var entry domain.SeatAuditLog
+		var oldStatus sql.NullString
+		var changedBy, bookingID, holdID uuid.NullUUID
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.SeatID,
+			&oldStatus,
+			&entry.NewStatus,
+			&changedBy,
+			&bookingID,
+			&holdID,
+			&entry.ChangedAt,
+			&entry.Reason,
+		); err != nil {
+			return nil, err
+		}
+
+		entry.OldStatus = domain.SeatStatus(oldStatus.String)
+
+		if changedBy.Valid {
+			entry.ChangedByUserID = &changedBy.UUID
+		}
+
+		if bookingID.Valid {
+			entry.BookingID = &bookingID.UUID
+		}
+
+		if holdID.Valid {
+			entry.HoldID = &holdID.UUID
+		}
+
+		logs = append(logs, entry)
+	}
+
+	return logs, rows.Err()
+}
+
+// insertSeatAudit writes an audit row inside the caller's transaction so the
+// history can never disagree with the seat row itself.
+func insertSeatAudit(ctx context.Context, tx *sql.Tx, entry domain.SeatAuditLog) error {
+	query := `
+	INSERT INTO seat_audit_logs (seat_id, old_status, new_status, changed_by_user_id, booking_id, hold_id, reason)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.ExecContext(ctx, query, entry.SeatID, entry.OldStatus, entry.NewStatus, entry.ChangedByUserID, entry.BookingID, entry.HoldID, entry.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to write seat audit log for seat %s: %w", entry.SeatID, err)
+	}
+
+	return nil
+}
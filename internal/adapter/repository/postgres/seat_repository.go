@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
 )
 
 type SeatRepository struct {
@@ -44,7 +46,7 @@ func (r *SeatRepository) GetByID(ctx context.Context, seatID uuid.UUID) (*domain
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("seat not found")
+			return nil, ports.ErrSeatNotFound
 		}
 
 		return nil, err
@@ -105,7 +107,78 @@ func (r *SeatRepository) GetAvailableSeatsByEvent(ctx context.Context, eventID u
 	return seats, nil
 }
 
-func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, currentVersion int) error {
+func (r *SeatRepository) GetSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	query := `
+	SELECT id, event_id, tier_id, section, row_number, seat_number, status, version
+	FROM event_seats
+	WHERE event_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var seats []domain.Seat
+	for rows.Next() {
+		var seat domain.Seat
+		if err := rows.Scan(
+			&seat.ID,
+			&seat.EventID,
+			&seat.TierID,
+			&seat.Section,
+			&seat.RowNumber,
+			&seat.SeatNumber,
+			&seat.Status,
+			&seat.Version,
+		); err != nil {
+			return nil, err
+		}
+
+		seats = append(seats, seat)
+	}
+
+	return seats, rows.Err()
+}
+
+func (r *SeatRepository) GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.PricingTier, error) {
+	query := `
+	SELECT id, event_id, name, price
+	FROM pricing_tiers
+	WHERE event_id = $1
+	ORDER BY price DESC, name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tiers []domain.PricingTier
+	for rows.Next() {
+		var tier domain.PricingTier
+		if err := rows.Scan(&tier.ID, &tier.EventID, &tier.Name, &tier.Price); err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, rows.Err()
+}
+
+func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID, currentVersion int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
 	query := `
 	UPDATE event_seats
 	SET status = $1,
@@ -115,7 +188,7 @@ func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, booking
 	WHERE id = $4 AND version = $5 AND status = 'AVAILABLE'
 	`
 
-	result, err := r.db.ExecContext(ctx, query, domain.SeatLocked, bookingID, time.Now(), seatID, currentVersion)
+	result, err := tx.ExecContext(ctx, query, domain.SeatLocked, bookingID, time.Now(), seatID, currentVersion)
 
 	if err != nil {
 		return err
@@ -127,23 +200,79 @@ func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, booking
 	}
 
 	if rowsAffected == 0 {
+		logging.FromContext(ctx).DebugContext(ctx, "Optimistic seat lock lost", "seat_id", seatID, "version", currentVersion)
 		return errors.New("optimistic lock failed: seat was modified by another transaction")
 	}
 
-	return nil
+	err = insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+		SeatID:          seatID,
+		OldStatus:       domain.SeatAvailable,
+		NewStatus:       domain.SeatLocked,
+		ChangedByUserID: &actorID,
+		BookingID:       &bookingID,
+		Reason:          domain.AuditReasonSeatLocked,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID) error {
+func (r *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT true FROM event_seats WHERE id = $1 FOR UPDATE`, seatID).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ports.ErrSeatNotFound
+		}
+
+		return err
+	}
+
+	// Only the booking's own lock is rolled back. A seat that was booked or
+	// re-locked by another booking in the meantime keeps its state.
 	query := `
 	UPDATE event_seats
 	SET status = 'AVAILABLE',
 		locked_by_booking_id = NULL,
 		locked_at = NULL,
 		version = version + 1
-	WHERE id = $1
+	WHERE id = $1 AND status = 'LOCKED' AND locked_by_booking_id = $2
 	`
 
-	_, err := r.db.ExecContext(ctx, query, seatID)
+	result, err := tx.ExecContext(ctx, query, seatID, bookingID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("seat is not locked by this booking")
+	}
+
+	err = insertSeatAudit(ctx, tx, domain.SeatAuditLog{
+		SeatID:          seatID,
+		OldStatus:       domain.SeatLocked,
+		NewStatus:       domain.SeatAvailable,
+		ChangedByUserID: &actorID,
+		BookingID:       &bookingID,
+		Reason:          domain.AuditReasonLockRolledBack,
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit()
 }
@@ -0,0 +1,20 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/srgjo27/scalable_ticket/internal/adapter/repository/repotest"
+)
+
+// TestBookingStress needs TEST_DATABASE_URL, see TestRepositoryContract.
+func TestBookingStress(t *testing.T) {
+	newHarness := harnessFactory(t)
+
+	cfg := repotest.StressConfig{Seats: 500, SeatsPerBooking: 3, Requests: 2000, Workers: 20, Seed: 1}
+	if testing.Short() {
+		cfg.Requests = 200
+	}
+
+	report := repotest.Stress(t, newHarness(t), cfg)
+	t.Log(report)
+}
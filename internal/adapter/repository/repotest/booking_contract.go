@@ -0,0 +1,278 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runBookingContract(t *testing.T, newHarness func(t *testing.T) Harness) {
+	ctx := context.Background()
+
+	t.Run("CreateBooking and GetByID round trip", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "2", "1")
+		userID := uuid.New()
+		created := createBooking(t, h, event.ID, userID, now(), seats...)
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, created.ID, booking.ID)
+		assert.Equal(t, userID, booking.UserID)
+		assert.Equal(t, event.ID, booking.EventID)
+		assert.Equal(t, domain.BookingPending, booking.Status)
+		assert.Equal(t, 200.0, booking.TotalAmount)
+		assert.WithinDuration(t, created.CreatedAt, booking.CreatedAt, 0)
+		assert.WithinDuration(t, created.ExpiresAt, booking.ExpiresAt, 0)
+		assert.Nil(t, booking.ConfirmedAt)
+		assert.Nil(t, booking.Payment)
+
+		require.Len(t, booking.Items, 2)
+		for i, want := range []domain.Seat{seats[1], seats[0]} {
+			item := booking.Items[i]
+			assert.Equal(t, want.ID, item.SeatID)
+			assert.Equal(t, created.ID, item.BookingID)
+			assert.Equal(t, 100.0, item.PriceAtBooking)
+			require.NotNil(t, item.Seat)
+			assert.Equal(t, want.SeatNumber, item.Seat.SeatNumber)
+			assert.Equal(t, domain.SeatLocked, item.Seat.Status)
+		}
+
+		_, err = h.Bookings.GetByID(ctx, uuid.New())
+		assert.EqualError(t, err, "booking not found")
+	})
+
+	t.Run("CreateBooking is all or nothing", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		bookingID := uuid.New()
+
+		err := h.Bookings.CreateBooking(ctx, &domain.Booking{
+			ID:          bookingID,
+			UserID:      uuid.New(),
+			EventID:     event.ID,
+			TotalAmount: 200,
+			Status:      domain.BookingPending,
+			CreatedAt:   now(),
+			ExpiresAt:   now().Add(10 * time.Minute),
+			Items: []domain.BookingItem{
+				{ID: uuid.New(), BookingID: bookingID, SeatID: seats[0].ID, PriceAtBooking: 100},
+				{ID: uuid.New(), BookingID: bookingID, SeatID: uuid.New(), PriceAtBooking: 100},
+			},
+		})
+		assert.Error(t, err)
+
+		_, err = h.Bookings.GetByID(ctx, bookingID)
+		assert.EqualError(t, err, "booking not found")
+	})
+
+	t.Run("CreateBooking rejects a duplicate ID", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		booking := createBooking(t, h, event.ID, uuid.New(), now(), seats...)
+
+		booking.Items = nil
+		assert.Error(t, h.Bookings.CreateBooking(ctx, booking))
+	})
+
+	t.Run("UpdateStatus CONFIRMED books the locked seats", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		userID := uuid.New()
+		created := createBooking(t, h, event.ID, userID, now(), seats...)
+
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, created.ID, domain.BookingConfirmed))
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BookingConfirmed, booking.Status)
+		assert.NotNil(t, booking.ConfirmedAt)
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatBooked, seat.Status)
+		require.NotNil(t, seat.LockedByBookingID)
+		assert.Equal(t, created.ID, *seat.LockedByBookingID)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonConfirmed, history[0].Reason)
+		assert.Equal(t, &userID, history[0].ChangedByUserID)
+	})
+
+	t.Run("UpdateStatus CANCELLED releases the seats", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		created := createBooking(t, h, event.ID, uuid.New(), now(), seats...)
+
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, created.ID, domain.BookingCancelled))
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BookingCancelled, booking.Status)
+		assert.Nil(t, booking.ConfirmedAt)
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatAvailable, seat.Status)
+		assert.Nil(t, seat.LockedByBookingID)
+	})
+
+	t.Run("UpdateStatus on an unknown booking", func(t *testing.T) {
+		h := newHarness(t)
+
+		assert.EqualError(t, h.Bookings.UpdateStatus(ctx, uuid.New(), domain.BookingConfirmed), "booking not found")
+	})
+
+	t.Run("GetExpiredBookings returns overdue pending bookings", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3")
+		past := now().Add(-time.Hour)
+
+		overdue := createBooking(t, h, event.ID, uuid.New(), past, seats[0])
+		current := createBooking(t, h, event.ID, uuid.New(), now(), seats[1])
+		confirmed := createBooking(t, h, event.ID, uuid.New(), past, seats[2])
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, confirmed.ID, domain.BookingConfirmed))
+
+		expired, err := h.Bookings.GetExpiredBookings(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, expired, overdue.ID)
+		assert.NotContains(t, expired, current.ID)
+		assert.NotContains(t, expired, confirmed.ID)
+
+		count, err := h.Bookings.CountExpiredBookings(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("CancelBooking expires the booking and releases its seats", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		created := createBooking(t, h, event.ID, uuid.New(), now().Add(-time.Hour), seats...)
+
+		require.NoError(t, h.Bookings.CancelBooking(ctx, created.ID))
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BookingExpired, booking.Status)
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatAvailable, seat.Status)
+		assert.Nil(t, seat.LockedByBookingID)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonExpired, history[0].Reason)
+		assert.Nil(t, history[0].ChangedByUserID)
+		assert.Equal(t, &created.ID, history[0].BookingID)
+	})
+
+	t.Run("CancelBooking leaves a confirmed booking and its seats alone", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		created := createBooking(t, h, event.ID, uuid.New(), now().Add(-time.Hour), seats...)
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, created.ID, domain.BookingConfirmed))
+
+		assert.ErrorIs(t, h.Bookings.CancelBooking(ctx, created.ID), ports.ErrBookingNotPending)
+		assert.ErrorIs(t, h.Bookings.CancelBooking(ctx, uuid.New()), ports.ErrBookingNotPending)
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BookingConfirmed, booking.Status)
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatBooked, seat.Status)
+		assert.Equal(t, &created.ID, seat.LockedByBookingID)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonConfirmed, history[0].Reason)
+	})
+
+	t.Run("GetByID attaches the latest payment", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		created := createBooking(t, h, event.ID, uuid.New(), now(), seats...)
+
+		failed := domain.Payment{ID: uuid.New(), BookingID: created.ID, Amount: 100, PaymentMethod: "CARD", Status: "FAILED", PaidAt: now().Add(-time.Minute)}
+		paid := domain.Payment{ID: uuid.New(), BookingID: created.ID, Amount: 100, PaymentMethod: "CARD", Status: "SUCCESS", PaidAt: now()}
+		require.NoError(t, h.Fixtures.AddPayment(failed))
+		require.NoError(t, h.Fixtures.AddPayment(paid))
+
+		booking, err := h.Bookings.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, booking.Payment)
+		assert.Equal(t, paid.ID, booking.Payment.ID)
+		assert.Equal(t, "SUCCESS", booking.PaymentStatus())
+	})
+
+	t.Run("ListByUser pages newest first", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3", "4", "5")
+		userID := uuid.New()
+		base := now().Add(-time.Hour)
+
+		oldest := createBooking(t, h, event.ID, userID, base, seats[0])
+		tieA := createBooking(t, h, event.ID, userID, base.Add(time.Minute), seats[1])
+		tieB := createBooking(t, h, event.ID, userID, base.Add(time.Minute), seats[2])
+		newest := createBooking(t, h, event.ID, userID, base.Add(2*time.Minute), seats[3])
+		createBooking(t, h, event.ID, uuid.New(), base.Add(3*time.Minute), seats[4])
+
+		tieFirst, tieSecond := tieA, tieB
+		if tieSecond.ID.String() > tieFirst.ID.String() {
+			tieFirst, tieSecond = tieSecond, tieFirst
+		}
+
+		page, err := h.Bookings.ListByUser(ctx, domain.BookingFilter{UserID: userID, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{newest.ID, tieFirst.ID}, bookingIDs(page))
+		require.Len(t, page[0].Items, 1)
+		assert.Equal(t, seats[3].ID, page[0].Items[0].SeatID)
+
+		last := page[len(page)-1]
+		page, err = h.Bookings.ListByUser(ctx, domain.BookingFilter{
+			UserID: userID,
+			After:  &domain.BookingCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+			Limit:  2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{tieSecond.ID, oldest.ID}, bookingIDs(page))
+	})
+
+	t.Run("ListByUser filters by status and event", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		otherEvent, otherSeats := seedEvent(t, h, "1")
+		userID := uuid.New()
+
+		pending := createBooking(t, h, event.ID, userID, now(), seats[0])
+		confirmed := createBooking(t, h, event.ID, userID, now(), seats[1])
+		elsewhere := createBooking(t, h, otherEvent.ID, userID, now(), otherSeats[0])
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, confirmed.ID, domain.BookingConfirmed))
+
+		status := domain.BookingPending
+		page, err := h.Bookings.ListByUser(ctx, domain.BookingFilter{UserID: userID, Status: &status, Limit: 10})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{pending.ID, elsewhere.ID}, bookingIDs(page))
+
+		page, err = h.Bookings.ListByUser(ctx, domain.BookingFilter{UserID: userID, Status: &status, EventID: &event.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{pending.ID}, bookingIDs(page))
+
+		page, err = h.Bookings.ListByUser(ctx, domain.BookingFilter{UserID: uuid.New(), Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, page)
+	})
+}
@@ -0,0 +1,73 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runEventContract(t *testing.T, newHarness func(t *testing.T) Harness) {
+	ctx := context.Background()
+
+	t.Run("GetByID", func(t *testing.T) {
+		h := newHarness(t)
+		seeded, _ := seedEvent(t, h)
+
+		event, err := h.Events.GetByID(ctx, seeded.ID)
+		require.NoError(t, err)
+		assert.Equal(t, seeded.ID, event.ID)
+		assert.Equal(t, seeded.Name, event.Name)
+		assert.WithinDuration(t, seeded.StartTime, event.StartTime, 0)
+		assert.WithinDuration(t, seeded.EndTime, event.EndTime, 0)
+		assert.True(t, event.IsActive)
+		assert.Equal(t, domain.ReservationDatabase, event.ReservationMode)
+
+		_, err = h.Events.GetByID(ctx, uuid.New())
+		assert.EqualError(t, err, "event not found")
+	})
+
+	t.Run("reservation mode defaults to DATABASE", func(t *testing.T) {
+		h := newHarness(t)
+		start := now()
+		event := domain.Event{ID: uuid.New(), Name: "Default Mode", StartTime: start, EndTime: start.Add(time.Hour), IsActive: true}
+		require.NoError(t, h.Fixtures.AddEvent(event))
+
+		stored, err := h.Events.GetByID(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReservationDatabase, stored.ReservationMode)
+	})
+
+	t.Run("SetReservationMode and ListByReservationMode", func(t *testing.T) {
+		h := newHarness(t)
+		active, _ := seedEvent(t, h)
+
+		start := now()
+		inactive := domain.Event{ID: uuid.New(), Name: "Inactive", StartTime: start, EndTime: start.Add(time.Hour), ReservationMode: domain.ReservationDatabase}
+		require.NoError(t, h.Fixtures.AddEvent(inactive))
+
+		require.NoError(t, h.Events.SetReservationMode(ctx, active.ID, domain.ReservationRedis))
+		require.NoError(t, h.Events.SetReservationMode(ctx, inactive.ID, domain.ReservationRedis))
+
+		event, err := h.Events.GetByID(ctx, active.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReservationRedis, event.ReservationMode)
+
+		events, err := h.Events.ListByReservationMode(ctx, domain.ReservationRedis)
+		require.NoError(t, err)
+
+		var ids []uuid.UUID
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+
+		assert.Contains(t, ids, active.ID)
+		assert.NotContains(t, ids, inactive.ID)
+
+		assert.EqualError(t, h.Events.SetReservationMode(ctx, uuid.New(), domain.ReservationRedis), "event not found")
+	})
+}
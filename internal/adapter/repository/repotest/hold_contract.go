@@ -0,0 +1,310 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runHoldContract(t *testing.T, newHarness func(t *testing.T) Harness) {
+	ctx := context.Background()
+
+	t.Run("CreateHold and GetHold round trip", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3")
+		releaseAt := now().Add(time.Hour)
+		created := createHold(t, h, event.ID, &releaseAt, now(), seats[0], seats[1])
+
+		hold, err := h.Holds.GetHold(ctx, created.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, created.ID, hold.ID)
+		assert.Equal(t, event.ID, hold.EventID)
+		assert.Equal(t, "Camera platform", hold.Name)
+		assert.Equal(t, "Broadcast", hold.Reason)
+		assert.Equal(t, "production", hold.Owner)
+		assert.Equal(t, domain.HoldActive, hold.Status)
+		assert.ElementsMatch(t, []uuid.UUID{seats[0].ID, seats[1].ID}, hold.SeatIDs)
+		require.NotNil(t, hold.ReleaseAt)
+		assert.WithinDuration(t, releaseAt, *hold.ReleaseAt, 0)
+		assert.Equal(t, created.CreatedBy, hold.CreatedBy)
+		assert.WithinDuration(t, created.CreatedAt, hold.CreatedAt, 0)
+		assert.Nil(t, hold.ClosedBy)
+		assert.Nil(t, hold.ClosedAt)
+		assert.Nil(t, hold.BookingID)
+
+		_, err = h.Holds.GetHold(ctx, uuid.New())
+		assert.EqualError(t, err, "hold not found")
+	})
+
+	t.Run("CreateHold takes seats off sale and audits them", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		hold := createHold(t, h, event.ID, nil, now(), seats[0])
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatUnavailable, seat.Status)
+		assert.Equal(t, 2, seat.Version)
+		assert.Nil(t, seat.LockedByBookingID)
+
+		available, err := h.Seats.GetAvailableSeatsByEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{seats[1].ID}, seatIDs(available))
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonHeld, history[0].Reason)
+		assert.Equal(t, domain.SeatAvailable, history[0].OldStatus)
+		assert.Equal(t, domain.SeatUnavailable, history[0].NewStatus)
+		assert.Equal(t, &hold.CreatedBy, history[0].ChangedByUserID)
+		assert.Equal(t, &hold.ID, history[0].HoldID)
+		assert.Nil(t, history[0].BookingID)
+	})
+
+	t.Run("CreateHold is all or nothing", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[1].ID, uuid.New(), uuid.New(), 1))
+
+		hold := newHold(event.ID, nil, now(), seats...)
+		err := h.Holds.CreateHold(ctx, hold)
+		assert.EqualError(t, err, "seat "+seats[1].ID.String()+" is not available")
+
+		_, err = h.Holds.GetHold(ctx, hold.ID)
+		assert.EqualError(t, err, "hold not found")
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatAvailable, seat.Status)
+		assert.Equal(t, 1, seat.Version)
+	})
+
+	t.Run("CreateHold rejects seats of another event", func(t *testing.T) {
+		h := newHarness(t)
+		event, _ := seedEvent(t, h)
+		_, other := seedEvent(t, h, "1")
+
+		err := h.Holds.CreateHold(ctx, newHold(event.ID, nil, now(), other[0]))
+		assert.EqualError(t, err, "seat not found: "+other[0].ID.String())
+	})
+
+	t.Run("ReleaseHold puts the seats back on sale", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		hold := createHold(t, h, event.ID, nil, now(), seats...)
+		actorID := uuid.New()
+
+		require.NoError(t, h.Holds.ReleaseHold(ctx, hold.ID, &actorID, domain.AuditReasonHoldReleased))
+
+		released, err := h.Holds.GetHold(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.HoldReleased, released.Status)
+		assert.Equal(t, &actorID, released.ClosedBy)
+		assert.NotNil(t, released.ClosedAt)
+
+		available, err := h.Seats.GetAvailableSeatsByEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, seatIDs(seats), seatIDs(available))
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, domain.AuditReasonHoldReleased, history[0].Reason)
+		assert.Equal(t, domain.SeatAvailable, history[0].NewStatus)
+		assert.Equal(t, &actorID, history[0].ChangedByUserID)
+		assert.Equal(t, &hold.ID, history[0].HoldID)
+
+		err = h.Holds.ReleaseHold(ctx, hold.ID, &actorID, domain.AuditReasonHoldReleased)
+		assert.ErrorIs(t, err, ports.ErrHoldNotActive)
+
+		err = h.Holds.ReleaseHold(ctx, uuid.New(), &actorID, domain.AuditReasonHoldReleased)
+		assert.EqualError(t, err, "hold not found")
+	})
+
+	t.Run("ReleaseHold without an actor records a system change", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1")
+		hold := createHold(t, h, event.ID, nil, now(), seats...)
+
+		require.NoError(t, h.Holds.ReleaseHold(ctx, hold.ID, nil, domain.AuditReasonHoldAutoReleased))
+
+		released, err := h.Holds.GetHold(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Nil(t, released.ClosedBy)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonHoldAutoReleased, history[0].Reason)
+		assert.Nil(t, history[0].ChangedByUserID)
+	})
+
+	t.Run("ConvertHold books the seats as a complimentary booking", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		hold := createHold(t, h, event.ID, nil, now(), seats...)
+		actorID, guestID := uuid.New(), uuid.New()
+		booking := complimentaryBooking(event.ID, guestID, seats...)
+
+		require.NoError(t, h.Holds.ConvertHold(ctx, hold.ID, booking, actorID))
+
+		converted, err := h.Holds.GetHold(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.HoldConverted, converted.Status)
+		assert.Equal(t, &actorID, converted.ClosedBy)
+		assert.Equal(t, &booking.ID, converted.BookingID)
+
+		got, err := h.Bookings.GetByID(ctx, booking.ID)
+		require.NoError(t, err)
+		assert.Equal(t, guestID, got.UserID)
+		assert.Equal(t, domain.BookingConfirmed, got.Status)
+		assert.Equal(t, 0.0, got.TotalAmount)
+		assert.NotNil(t, got.ConfirmedAt)
+		assert.Len(t, got.Items, 2)
+		assert.Equal(t, domain.PaymentStatusSuccess, got.PaymentStatus())
+		assert.Equal(t, domain.PaymentMethodComplimentary, got.Payment.PaymentMethod)
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatBooked, seat.Status)
+		require.NotNil(t, seat.LockedByBookingID)
+		assert.Equal(t, booking.ID, *seat.LockedByBookingID)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonComplimentary, history[0].Reason)
+		assert.Equal(t, domain.SeatUnavailable, history[0].OldStatus)
+		assert.Equal(t, &actorID, history[0].ChangedByUserID)
+		assert.Equal(t, &booking.ID, history[0].BookingID)
+		assert.Equal(t, &hold.ID, history[0].HoldID)
+
+		err = h.Holds.ConvertHold(ctx, hold.ID, complimentaryBooking(event.ID, guestID, seats...), actorID)
+		assert.ErrorIs(t, err, ports.ErrHoldNotActive)
+	})
+
+	t.Run("ConvertHold needs a booking for exactly the held seats", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+		hold := createHold(t, h, event.ID, nil, now(), seats...)
+		booking := complimentaryBooking(event.ID, uuid.New(), seats[0])
+
+		assert.Error(t, h.Holds.ConvertHold(ctx, hold.ID, booking, uuid.New()))
+
+		_, err := h.Bookings.GetByID(ctx, booking.ID)
+		assert.EqualError(t, err, "booking not found")
+
+		still, err := h.Holds.GetHold(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.HoldActive, still.Status)
+	})
+
+	t.Run("ListHoldsByEvent orders newest first and filters by status", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3")
+		_, other := seedEvent(t, h, "1")
+
+		base := now()
+		oldest := createHold(t, h, event.ID, nil, base.Add(-2*time.Minute), seats[0])
+		middle := createHold(t, h, event.ID, nil, base.Add(-time.Minute), seats[1])
+		newest := createHold(t, h, event.ID, nil, base, seats[2])
+		createHold(t, h, other[0].EventID, nil, base, other[0])
+
+		require.NoError(t, h.Holds.ReleaseHold(ctx, middle.ID, nil, domain.AuditReasonHoldReleased))
+
+		holds, err := h.Holds.ListHoldsByEvent(ctx, event.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{newest.ID, middle.ID, oldest.ID}, holdIDs(holds))
+		assert.Equal(t, []uuid.UUID{seats[2].ID}, holds[0].SeatIDs)
+
+		active := domain.HoldActive
+		holds, err = h.Holds.ListHoldsByEvent(ctx, event.ID, &active)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{newest.ID, oldest.ID}, holdIDs(holds))
+	})
+
+	t.Run("GetDueHolds returns active holds past their release time", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3", "4")
+
+		past, future := now().Add(-time.Minute), now().Add(time.Hour)
+		due := createHold(t, h, event.ID, &past, now(), seats[0])
+		createHold(t, h, event.ID, &future, now(), seats[1])
+		createHold(t, h, event.ID, nil, now(), seats[2])
+		released := createHold(t, h, event.ID, &past, now(), seats[3])
+		require.NoError(t, h.Holds.ReleaseHold(ctx, released.ID, nil, domain.AuditReasonHoldReleased))
+
+		ids, err := h.Holds.GetDueHolds(ctx, now())
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{due.ID}, ids)
+	})
+}
+
+func newHold(eventID uuid.UUID, releaseAt *time.Time, createdAt time.Time, seats ...domain.Seat) *domain.InventoryHold {
+	return &domain.InventoryHold{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		Name:      "Camera platform",
+		Reason:    "Broadcast",
+		Owner:     "production",
+		SeatIDs:   seatIDs(seats),
+		Status:    domain.HoldActive,
+		ReleaseAt: releaseAt,
+		CreatedBy: uuid.New(),
+		CreatedAt: createdAt,
+	}
+}
+
+func createHold(t testing.TB, h Harness, eventID uuid.UUID, releaseAt *time.Time, createdAt time.Time, seats ...domain.Seat) *domain.InventoryHold {
+	t.Helper()
+
+	hold := newHold(eventID, releaseAt, createdAt, seats...)
+	require.NoError(t, h.Holds.CreateHold(context.Background(), hold))
+
+	return hold
+}
+
+// complimentaryBooking builds the booking HoldService converts a hold into.
+func complimentaryBooking(eventID, userID uuid.UUID, seats ...domain.Seat) *domain.Booking {
+	at := now()
+	booking := &domain.Booking{
+		ID:          uuid.New(),
+		UserID:      userID,
+		EventID:     eventID,
+		Status:      domain.BookingConfirmed,
+		CreatedAt:   at,
+		ExpiresAt:   at,
+		ConfirmedAt: &at,
+	}
+
+	for _, seat := range seats {
+		booking.Items = append(booking.Items, domain.BookingItem{ID: uuid.New(), BookingID: booking.ID, SeatID: seat.ID})
+	}
+
+	booking.Payment = &domain.Payment{
+		ID:            uuid.New(),
+		BookingID:     booking.ID,
+		PaymentMethod: domain.PaymentMethodComplimentary,
+		Status:        domain.PaymentStatusSuccess,
+		PaidAt:        at,
+	}
+
+	return booking
+}
+
+func holdIDs(holds []domain.InventoryHold) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(holds))
+	for _, hold := range holds {
+		ids = append(ids, hold.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,139 @@
+// Package repotest is a contract test suite for implementations of the
+// repository ports. Every adapter runs the same suite from its own tests, so
+// the in-memory adapter and PostgreSQL are held to identical behaviour.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures inserts the rows that the ports have no write method for.
+type Fixtures interface {
+	AddEvent(event domain.Event) error
+	AddTier(tier domain.PricingTier) error
+	AddSeat(seat domain.Seat) error
+	AddPayment(payment domain.Payment) error
+}
+
+// Harness is one set of repositories sharing the same storage.
+type Harness struct {
+	Seats    ports.SeatRepository
+	Bookings ports.BookingRepository
+	Audit    ports.SeatAuditRepository
+	Events   ports.EventRepository
+	Holds    ports.HoldRepository
+	Fixtures Fixtures
+}
+
+// Run runs the whole contract. newHarness is called once per subtest and
+// should return empty storage, or storage whose existing rows can't collide
+// with freshly generated UUIDs.
+func Run(t *testing.T, newHarness func(t *testing.T) Harness) {
+	t.Run("SeatRepository", func(t *testing.T) { runSeatContract(t, newHarness) })
+	t.Run("BookingRepository", func(t *testing.T) { runBookingContract(t, newHarness) })
+	t.Run("EventRepository", func(t *testing.T) { runEventContract(t, newHarness) })
+	t.Run("HoldRepository", func(t *testing.T) { runHoldContract(t, newHarness) })
+}
+
+// now returns the current time at the precision PostgreSQL stores.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// seedEvent inserts an active event with one tier and a seat for every seat
+// number, all in section A, row 1.
+func seedEvent(t testing.TB, h Harness, seatNumbers ...string) (domain.Event, []domain.Seat) {
+	t.Helper()
+
+	start := now().Add(30 * 24 * time.Hour)
+	event := domain.Event{
+		ID:              uuid.New(),
+		Name:            "Contract Test Event",
+		StartTime:       start,
+		EndTime:         start.Add(4 * time.Hour),
+		IsActive:        true,
+		ReservationMode: domain.ReservationDatabase,
+	}
+	require.NoError(t, h.Fixtures.AddEvent(event))
+
+	tier := domain.PricingTier{ID: uuid.New(), EventID: event.ID, Name: "REGULAR", Price: 100}
+	require.NoError(t, h.Fixtures.AddTier(tier))
+
+	seats := make([]domain.Seat, 0, len(seatNumbers))
+	for _, number := range seatNumbers {
+		seat := domain.Seat{
+			ID:         uuid.New(),
+			EventID:    event.ID,
+			TierID:     tier.ID,
+			Section:    "A",
+			RowNumber:  "1",
+			SeatNumber: number,
+			Status:     domain.SeatAvailable,
+			Version:    1,
+		}
+		require.NoError(t, h.Fixtures.AddSeat(seat))
+
+		seats = append(seats, seat)
+	}
+
+	return event, seats
+}
+
+// createBooking locks the seats for a new PENDING booking and persists it,
+// the same way BookingService does.
+func createBooking(t testing.TB, h Harness, eventID, userID uuid.UUID, createdAt time.Time, seats ...domain.Seat) *domain.Booking {
+	t.Helper()
+
+	ctx := context.Background()
+	booking := &domain.Booking{
+		ID:        uuid.New(),
+		UserID:    userID,
+		EventID:   eventID,
+		Status:    domain.BookingPending,
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(10 * time.Minute),
+	}
+
+	for _, seat := range seats {
+		current, err := h.Seats.GetByID(ctx, seat.ID)
+		require.NoError(t, err)
+		require.NoError(t, h.Seats.LockSeat(ctx, seat.ID, booking.ID, userID, current.Version))
+
+		booking.TotalAmount += 100
+		booking.Items = append(booking.Items, domain.BookingItem{
+			ID:             uuid.New(),
+			BookingID:      booking.ID,
+			SeatID:         seat.ID,
+			PriceAtBooking: 100,
+		})
+	}
+
+	require.NoError(t, h.Bookings.CreateBooking(ctx, booking))
+
+	return booking
+}
+
+func seatIDs(seats []domain.Seat) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(seats))
+	for _, seat := range seats {
+		ids = append(ids, seat.ID)
+	}
+
+	return ids
+}
+
+func bookingIDs(bookings []domain.Booking) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(bookings))
+	for _, booking := range bookings {
+		ids = append(ids, booking.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,212 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runSeatContract(t *testing.T, newHarness func(t *testing.T) Harness) {
+	ctx := context.Background()
+
+	t.Run("GetByID", func(t *testing.T) {
+		h := newHarness(t)
+		_, seats := seedEvent(t, h, "1")
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, seats[0], *seat)
+
+		_, err = h.Seats.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, ports.ErrSeatNotFound)
+	})
+
+	t.Run("GetAvailableSeatsByEvent skips locked seats and other events", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2", "3")
+		seedEvent(t, h, "1")
+
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[1].ID, uuid.New(), uuid.New(), 1))
+
+		available, err := h.Seats.GetAvailableSeatsByEvent(ctx, event.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{seats[0].ID, seats[2].ID}, seatIDs(available))
+
+		for _, seat := range available {
+			assert.Equal(t, domain.SeatAvailable, seat.Status)
+			assert.Equal(t, seats[0].TierID, seat.TierID)
+		}
+	})
+
+	t.Run("GetSeatsByEvent returns every status", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[0].ID, uuid.New(), uuid.New(), 1))
+
+		all, err := h.Seats.GetSeatsByEvent(ctx, event.ID)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		statuses := map[uuid.UUID]domain.SeatStatus{}
+		for _, seat := range all {
+			statuses[seat.ID] = seat.Status
+		}
+
+		assert.Equal(t, domain.SeatLocked, statuses[seats[0].ID])
+		assert.Equal(t, domain.SeatAvailable, statuses[seats[1].ID])
+	})
+
+	t.Run("GetTiersByEvent orders by price then name", func(t *testing.T) {
+		h := newHarness(t)
+		event, _ := seedEvent(t, h)
+
+		for _, tier := range []domain.PricingTier{
+			{ID: uuid.New(), EventID: event.ID, Name: "VIP", Price: 500},
+			{ID: uuid.New(), EventID: event.ID, Name: "BALCONY", Price: 100},
+		} {
+			require.NoError(t, h.Fixtures.AddTier(tier))
+		}
+
+		tiers, err := h.Seats.GetTiersByEvent(ctx, event.ID)
+		require.NoError(t, err)
+
+		var names []string
+		for _, tier := range tiers {
+			names = append(names, tier.Name)
+		}
+
+		assert.Equal(t, []string{"VIP", "BALCONY", "REGULAR"}, names)
+	})
+
+	t.Run("LockSeat checks version and status", func(t *testing.T) {
+		h := newHarness(t)
+		_, seats := seedEvent(t, h, "1")
+		bookingID := uuid.New()
+
+		assert.ErrorContains(t, h.Seats.LockSeat(ctx, seats[0].ID, bookingID, uuid.New(), 2), "optimistic lock failed")
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[0].ID, bookingID, uuid.New(), 1))
+		assert.ErrorContains(t, h.Seats.LockSeat(ctx, seats[0].ID, uuid.New(), uuid.New(), 2), "optimistic lock failed")
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatLocked, seat.Status)
+		assert.Equal(t, 2, seat.Version)
+		require.NotNil(t, seat.LockedByBookingID)
+		assert.Equal(t, bookingID, *seat.LockedByBookingID)
+		assert.NotNil(t, seat.LockedAt)
+	})
+
+	t.Run("LockSeat lets exactly one concurrent caller win", func(t *testing.T) {
+		h := newHarness(t)
+		_, seats := seedEvent(t, h, "1")
+
+		const callers = 10
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			wins int
+		)
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := h.Seats.LockSeat(ctx, seats[0].ID, uuid.New(), uuid.New(), 1); err == nil {
+					mu.Lock()
+					wins++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 1, wins)
+	})
+
+	t.Run("UnlockSeat releases the seat", func(t *testing.T) {
+		h := newHarness(t)
+		_, seats := seedEvent(t, h, "1")
+
+		bookingID := uuid.New()
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[0].ID, bookingID, uuid.New(), 1))
+		require.NoError(t, h.Seats.UnlockSeat(ctx, seats[0].ID, bookingID, uuid.New()))
+
+		seat, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatAvailable, seat.Status)
+		assert.Equal(t, 3, seat.Version)
+		assert.Nil(t, seat.LockedByBookingID)
+		assert.Nil(t, seat.LockedAt)
+
+		assert.EqualError(t, h.Seats.UnlockSeat(ctx, uuid.New(), uuid.New(), uuid.New()), "seat not found")
+	})
+
+	t.Run("UnlockSeat only rolls back the booking's own lock", func(t *testing.T) {
+		h := newHarness(t)
+		event, seats := seedEvent(t, h, "1", "2")
+
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[0].ID, uuid.New(), uuid.New(), 1))
+		assert.EqualError(t, h.Seats.UnlockSeat(ctx, seats[0].ID, uuid.New(), uuid.New()), "seat is not locked by this booking")
+
+		booked := createBooking(t, h, event.ID, uuid.New(), now(), seats[1])
+		require.NoError(t, h.Bookings.UpdateStatus(ctx, booked.ID, domain.BookingConfirmed))
+		assert.EqualError(t, h.Seats.UnlockSeat(ctx, seats[1].ID, booked.ID, uuid.New()), "seat is not locked by this booking")
+
+		locked, err := h.Seats.GetByID(ctx, seats[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatLocked, locked.Status)
+		assert.Equal(t, 2, locked.Version)
+
+		seat, err := h.Seats.GetByID(ctx, seats[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SeatBooked, seat.Status)
+		assert.Equal(t, &booked.ID, seat.LockedByBookingID)
+
+		history, err := h.Audit.ListBySeat(ctx, seats[1].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, domain.AuditReasonConfirmed, history[0].Reason)
+	})
+
+	t.Run("lock and unlock are audited newest first", func(t *testing.T) {
+		h := newHarness(t)
+		_, seats := seedEvent(t, h, "1")
+		bookingID, lockedBy, unlockedBy := uuid.New(), uuid.New(), uuid.New()
+
+		require.NoError(t, h.Seats.LockSeat(ctx, seats[0].ID, bookingID, lockedBy, 1))
+		require.NoError(t, h.Seats.UnlockSeat(ctx, seats[0].ID, bookingID, unlockedBy))
+
+		history, err := h.Audit.ListBySeat(ctx, seats[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		rolledBack, locked := history[0], history[1]
+
+		assert.Equal(t, domain.AuditReasonLockRolledBack, rolledBack.Reason)
+		assert.Equal(t, domain.SeatLocked, rolledBack.OldStatus)
+		assert.Equal(t, domain.SeatAvailable, rolledBack.NewStatus)
+		assert.Equal(t, &unlockedBy, rolledBack.ChangedByUserID)
+		assert.Equal(t, &bookingID, rolledBack.BookingID)
+
+		assert.Equal(t, domain.AuditReasonSeatLocked, locked.Reason)
+		assert.Equal(t, domain.SeatAvailable, locked.OldStatus)
+		assert.Equal(t, domain.SeatLocked, locked.NewStatus)
+		assert.Equal(t, &lockedBy, locked.ChangedByUserID)
+		assert.Equal(t, &bookingID, locked.BookingID)
+		assert.False(t, locked.ChangedAt.After(rolledBack.ChangedAt))
+
+		limited, err := h.Audit.ListBySeat(ctx, seats[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, rolledBack.ID, limited[0].ID)
+	})
+}
@@ -0,0 +1,253 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/cache/noop"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+)
+
+// StressConfig shapes a stress run. Every request books SeatsPerBooking
+// adjacent seats starting at a random seat, so requests overlap heavily.
+type StressConfig struct {
+	Seats           int
+	SeatsPerBooking int
+	Requests        int
+	Workers         int
+	Seed            int64
+}
+
+// StressReport summarises a stress run.
+type StressReport struct {
+	Requests  int
+	Succeeded int
+	Conflicts int
+	Failures  int
+	Elapsed   time.Duration
+}
+
+// Throughput is CreateBooking calls per second, successful or not.
+func (r StressReport) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// ConflictRate is the share of calls rejected because a seat was taken.
+func (r StressReport) ConflictRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	return float64(r.Conflicts) / float64(r.Requests)
+}
+
+func (r StressReport) String() string {
+	return fmt.Sprintf("%d requests in %s (%.0f req/s): %d booked, %d conflicts (%.1f%%), %d failures",
+		r.Requests, r.Elapsed.Round(time.Millisecond), r.Throughput(), r.Succeeded, r.Conflicts, 100*r.ConflictRate(), r.Failures)
+}
+
+// Stress seeds one event and fires cfg.Requests concurrent CreateBooking calls
+// at it through a real BookingService backed by h, with caching disabled.
+// Afterwards it checks the booking invariants and fails tb if any is broken
+// or if a call failed for any reason other than a seat conflict. When tb is a
+// *testing.B only the calls are timed and throughput is reported as metrics.
+func Stress(tb testing.TB, h Harness, cfg StressConfig) StressReport {
+	tb.Helper()
+
+	if cfg.SeatsPerBooking < 1 || cfg.SeatsPerBooking > cfg.Seats {
+		tb.Fatalf("SeatsPerBooking must be between 1 and Seats, got %d", cfg.SeatsPerBooking)
+	}
+
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+
+	numbers := make([]string, cfg.Seats)
+	for i := range numbers {
+		numbers[i] = strconv.Itoa(i + 1)
+	}
+
+	event, seats := seedEvent(tb, h, numbers...)
+	service := services.NewBookingService(h.Seats, h.Bookings, noop.NewSeatCache(), noop.NewCache())
+
+	requests := make([]services.CreateBookingRequest, cfg.Requests)
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	for i := range requests {
+		start := rng.Intn(cfg.Seats - cfg.SeatsPerBooking + 1)
+		picked := make([]string, 0, cfg.SeatsPerBooking)
+		for _, seat := range seats[start : start+cfg.SeatsPerBooking] {
+			picked = append(picked, seat.ID.String())
+		}
+
+		rng.Shuffle(len(picked), func(a, b int) { picked[a], picked[b] = picked[b], picked[a] })
+
+		requests[i] = services.CreateBookingRequest{
+			UserID:  uuid.NewString(),
+			EventID: event.ID.String(),
+			SeatIDs: picked,
+		}
+	}
+
+	var (
+		next      atomic.Int64
+		mu        sync.Mutex
+		booked    []uuid.UUID
+		conflicts int
+		failures  []error
+		wg        sync.WaitGroup
+	)
+
+	if b, ok := tb.(*testing.B); ok {
+		b.ResetTimer()
+	}
+
+	started := time.Now()
+
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(requests) {
+					return
+				}
+
+				resp, err := service.CreateBooking(context.Background(), requests[i])
+
+				mu.Lock()
+				switch {
+				case err == nil:
+					booked = append(booked, uuid.MustParse(resp.BookingID))
+				case isSeatConflict(err):
+					conflicts++
+				default:
+					failures = append(failures, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	report := StressReport{
+		Requests:  cfg.Requests,
+		Succeeded: len(booked),
+		Conflicts: conflicts,
+		Failures:  len(failures),
+		Elapsed:   time.Since(started),
+	}
+
+	if b, ok := tb.(*testing.B); ok {
+		b.StopTimer()
+		b.ReportMetric(report.Throughput(), "req/s")
+		b.ReportMetric(report.ConflictRate(), "conflict-rate")
+	}
+
+	for i, err := range failures {
+		if i == 5 {
+			tb.Errorf("... and %d more unexpected failures", len(failures)-i)
+			break
+		}
+
+		tb.Errorf("unexpected CreateBooking failure: %v", err)
+	}
+
+	CheckBookingInvariants(tb, h, seats, booked)
+
+	return report
+}
+
+// isSeatConflict reports whether CreateBooking lost a race for a seat, which
+// is the expected outcome for overlapping requests.
+func isSeatConflict(err error) bool {
+	return errors.Is(err, services.ErrSeatUnavailable)
+}
+
+// CheckBookingInvariants verifies that no seat is held by two bookings, that
+// booking totals equal the sum of their items, and that every LOCKED seat
+// belongs to a PENDING booking that contains it. bookingIDs must be every
+// booking that was created for the seats.
+func CheckBookingInvariants(tb testing.TB, h Harness, seats []domain.Seat, bookingIDs []uuid.UUID) {
+	tb.Helper()
+
+	ctx := context.Background()
+	owners := make(map[uuid.UUID]uuid.UUID)
+	statuses := make(map[uuid.UUID]domain.BookingStatus)
+
+	for _, id := range bookingIDs {
+		booking, err := h.Bookings.GetByID(ctx, id)
+		if err != nil {
+			tb.Errorf("booking %s: %v", id, err)
+			continue
+		}
+
+		statuses[id] = booking.Status
+
+		if len(booking.Items) == 0 {
+			tb.Errorf("booking %s has no items", id)
+		}
+
+		var sum float64
+		for _, item := range booking.Items {
+			sum += item.PriceAtBooking
+
+			if other, taken := owners[item.SeatID]; taken {
+				tb.Errorf("seat %s is held by bookings %s and %s", item.SeatID, other, id)
+			}
+
+			owners[item.SeatID] = id
+		}
+
+		if math.Abs(sum-booking.TotalAmount) > 0.005 {
+			tb.Errorf("booking %s total %.2f does not match its items %.2f", id, booking.TotalAmount, sum)
+		}
+	}
+
+	for _, s := range seats {
+		seat, err := h.Seats.GetByID(ctx, s.ID)
+		if err != nil {
+			tb.Errorf("seat %s: %v", s.ID, err)
+			continue
+		}
+
+		owner, owned := owners[seat.ID]
+
+		switch seat.Status {
+		case domain.SeatLocked:
+			if seat.LockedByBookingID == nil {
+				tb.Errorf("seat %s is LOCKED without a booking", seat.ID)
+				continue
+			}
+
+			if !owned || owner != *seat.LockedByBookingID {
+				tb.Errorf("seat %s is LOCKED by %s, which has no item for it", seat.ID, *seat.LockedByBookingID)
+				continue
+			}
+
+			if statuses[owner] != domain.BookingPending {
+				tb.Errorf("seat %s is LOCKED by booking %s in status %s", seat.ID, owner, statuses[owner])
+			}
+		case domain.SeatAvailable:
+			if owned && statuses[owner] == domain.BookingPending {
+				tb.Errorf("seat %s is AVAILABLE but held by pending booking %s", seat.ID, owner)
+			}
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc/stats"
+)
+
+// GRPCServerHandler starts a server span for every gRPC call, named after its
+// full method, continuing the trace from incoming traceparent metadata.
+func GRPCServerHandler() stats.Handler {
+	return otelgrpc.NewServerHandler()
+}
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a server span for every request, continuing the trace
+// from an incoming W3C traceparent header. Once the ServeMux inside next has
+// routed the request, the span is renamed after the matched pattern.
+func Middleware(next http.Handler) http.Handler {
+	named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if r.Pattern == "" {
+			return
+		}
+
+		route := r.Pattern
+		if !strings.Contains(route, " ") {
+			route = r.Method + " " + route
+		}
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(route)
+		span.SetAttributes(attribute.String("http.route", route[strings.Index(route, " ")+1:]))
+	})
+
+	return otelhttp.NewHandler(named, "HTTP request")
+}
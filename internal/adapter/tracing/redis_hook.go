@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RedisHook returns a go-redis hook that starts a span for every command and
+// pipeline. goredis.Nil (a missing key) is not treated as an error.
+func RedisHook() goredis.Hook {
+	return redisHook{}
+}
+
+type redisHook struct{}
+
+var redisSystem = attribute.String("db.system", "redis")
+
+func (redisHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		ctx, span := startSpan(ctx, "redis.dial", redisSystem, attribute.String("server.address", addr))
+		conn, err := next(ctx, network, addr)
+		endSpan(span, err)
+
+		return conn, err
+	}
+}
+
+func (redisHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		ctx, span := startSpan(ctx, "redis."+cmd.Name(), redisSystem, attribute.String("db.operation.name", cmd.Name()))
+		err := next(ctx, cmd)
+		endSpan(span, redisError(err))
+
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		ctx, span := startSpan(ctx, "redis.pipeline", redisSystem, attribute.Int("db.operation.batch.size", len(cmds)))
+		err := next(ctx, cmds)
+		endSpan(span, redisError(err))
+
+		return err
+	}
+}
+
+func redisError(err error) error {
+	if errors.Is(err, goredis.Nil) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,257 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func eventAttr(id uuid.UUID) attribute.KeyValue   { return attribute.String("event.id", id.String()) }
+func seatAttr(id uuid.UUID) attribute.KeyValue    { return attribute.String("seat.id", id.String()) }
+func bookingAttr(id uuid.UUID) attribute.KeyValue { return attribute.String("booking.id", id.String()) }
+func holdAttr(id uuid.UUID) attribute.KeyValue    { return attribute.String("hold.id", id.String()) }
+
+// SeatRepository wraps a ports.SeatRepository with one span per call.
+type SeatRepository struct {
+	next ports.SeatRepository
+}
+
+func NewSeatRepository(next ports.SeatRepository) *SeatRepository {
+	return &SeatRepository{next: next}
+}
+
+func (r *SeatRepository) GetByID(ctx context.Context, seatID uuid.UUID) (*domain.Seat, error) {
+	ctx, span := startSpan(ctx, "SeatRepository.GetByID", seatAttr(seatID))
+	seat, err := r.next.GetByID(ctx, seatID)
+	endSpan(span, err)
+
+	return seat, err
+}
+
+func (r *SeatRepository) GetAvailableSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	ctx, span := startSpan(ctx, "SeatRepository.GetAvailableSeatsByEvent", eventAttr(eventID))
+	seats, err := r.next.GetAvailableSeatsByEvent(ctx, eventID)
+	span.SetAttributes(attribute.Int("seat.count", len(seats)))
+	endSpan(span, err)
+
+	return seats, err
+}
+
+func (r *SeatRepository) GetSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	ctx, span := startSpan(ctx, "SeatRepository.GetSeatsByEvent", eventAttr(eventID))
+	seats, err := r.next.GetSeatsByEvent(ctx, eventID)
+	span.SetAttributes(attribute.Int("seat.count", len(seats)))
+	endSpan(span, err)
+
+	return seats, err
+}
+
+func (r *SeatRepository) GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.PricingTier, error) {
+	ctx, span := startSpan(ctx, "SeatRepository.GetTiersByEvent", eventAttr(eventID))
+	tiers, err := r.next.GetTiersByEvent(ctx, eventID)
+	endSpan(span, err)
+
+	return tiers, err
+}
+
+func (r *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID, currentVersion int) error {
+	ctx, span := startSpan(ctx, "SeatRepository.LockSeat",
+		seatAttr(seatID), bookingAttr(bookingID), attribute.Int("seat.version", currentVersion))
+	err := r.next.LockSeat(ctx, seatID, bookingID, actorID, currentVersion)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "SeatRepository.UnlockSeat", seatAttr(seatID), bookingAttr(bookingID))
+	err := r.next.UnlockSeat(ctx, seatID, bookingID, actorID)
+	endSpan(span, err)
+
+	return err
+}
+
+// BookingRepository wraps a ports.BookingRepository with one span per call.
+type BookingRepository struct {
+	next ports.BookingRepository
+}
+
+func NewBookingRepository(next ports.BookingRepository) *BookingRepository {
+	return &BookingRepository{next: next}
+}
+
+func (r *BookingRepository) CreateBooking(ctx context.Context, booking *domain.Booking) error {
+	ctx, span := startSpan(ctx, "BookingRepository.CreateBooking",
+		bookingAttr(booking.ID), eventAttr(booking.EventID), attribute.Int("booking.seat_count", len(booking.Items)))
+	err := r.next.CreateBooking(ctx, booking)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *BookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUID, status domain.BookingStatus) error {
+	ctx, span := startSpan(ctx, "BookingRepository.UpdateStatus",
+		bookingAttr(bookingID), attribute.String("booking.status", string(status)))
+	err := r.next.UpdateStatus(ctx, bookingID, status)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUID, error) {
+	ctx, span := startSpan(ctx, "BookingRepository.GetExpiredBookings")
+	ids, err := r.next.GetExpiredBookings(ctx)
+	span.SetAttributes(attribute.Int("booking.count", len(ids)))
+	endSpan(span, err)
+
+	return ids, err
+}
+
+func (r *BookingRepository) CountExpiredBookings(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "BookingRepository.CountExpiredBookings")
+	count, err := r.next.CountExpiredBookings(ctx)
+	span.SetAttributes(attribute.Int("booking.count", count))
+	endSpan(span, err)
+
+	return count, err
+}
+
+func (r *BookingRepository) CancelBooking(ctx context.Context, bookingID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "BookingRepository.CancelBooking", bookingAttr(bookingID))
+	err := r.next.CancelBooking(ctx, bookingID)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *BookingRepository) GetByID(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	ctx, span := startSpan(ctx, "BookingRepository.GetByID", bookingAttr(bookingID))
+	booking, err := r.next.GetByID(ctx, bookingID)
+	endSpan(span, err)
+
+	return booking, err
+}
+
+func (r *BookingRepository) ListByUser(ctx context.Context, filter domain.BookingFilter) ([]domain.Booking, error) {
+	ctx, span := startSpan(ctx, "BookingRepository.ListByUser", attribute.Int("query.limit", filter.Limit))
+	bookings, err := r.next.ListByUser(ctx, filter)
+	span.SetAttributes(attribute.Int("booking.count", len(bookings)))
+	endSpan(span, err)
+
+	return bookings, err
+}
+
+// EventRepository wraps a ports.EventRepository with one span per call.
+type EventRepository struct {
+	next ports.EventRepository
+}
+
+func NewEventRepository(next ports.EventRepository) *EventRepository {
+	return &EventRepository{next: next}
+}
+
+func (r *EventRepository) GetByID(ctx context.Context, eventID uuid.UUID) (*domain.Event, error) {
+	ctx, span := startSpan(ctx, "EventRepository.GetByID", eventAttr(eventID))
+	event, err := r.next.GetByID(ctx, eventID)
+	endSpan(span, err)
+
+	return event, err
+}
+
+func (r *EventRepository) ListByReservationMode(ctx context.Context, mode domain.ReservationMode) ([]domain.Event, error) {
+	ctx, span := startSpan(ctx, "EventRepository.ListByReservationMode", attribute.String("event.reservation_mode", string(mode)))
+	events, err := r.next.ListByReservationMode(ctx, mode)
+	endSpan(span, err)
+
+	return events, err
+}
+
+func (r *EventRepository) SetReservationMode(ctx context.Context, eventID uuid.UUID, mode domain.ReservationMode) error {
+	ctx, span := startSpan(ctx, "EventRepository.SetReservationMode",
+		eventAttr(eventID), attribute.String("event.reservation_mode", string(mode)))
+	err := r.next.SetReservationMode(ctx, eventID, mode)
+	endSpan(span, err)
+
+	return err
+}
+
+// SeatAuditRepository wraps a ports.SeatAuditRepository with one span per call.
+type SeatAuditRepository struct {
+	next ports.SeatAuditRepository
+}
+
+func NewSeatAuditRepository(next ports.SeatAuditRepository) *SeatAuditRepository {
+	return &SeatAuditRepository{next: next}
+}
+
+func (r *SeatAuditRepository) ListBySeat(ctx context.Context, seatID uuid.UUID, limit int) ([]domain.SeatAuditLog, error) {
+	ctx, span := startSpan(ctx, "SeatAuditRepository.ListBySeat", seatAttr(seatID), attribute.Int("query.limit", limit))
+	logs, err := r.next.ListBySeat(ctx, seatID, limit)
+	endSpan(span, err)
+
+	return logs, err
+}
+
+// HoldRepository wraps a ports.HoldRepository with one span per call.
+type HoldRepository struct {
+	next ports.HoldRepository
+}
+
+func NewHoldRepository(next ports.HoldRepository) *HoldRepository {
+	return &HoldRepository{next: next}
+}
+
+func (r *HoldRepository) CreateHold(ctx context.Context, hold *domain.InventoryHold) error {
+	ctx, span := startSpan(ctx, "HoldRepository.CreateHold",
+		holdAttr(hold.ID), eventAttr(hold.EventID), attribute.Int("seat.count", len(hold.SeatIDs)))
+	err := r.next.CreateHold(ctx, hold)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *HoldRepository) GetHold(ctx context.Context, holdID uuid.UUID) (*domain.InventoryHold, error) {
+	ctx, span := startSpan(ctx, "HoldRepository.GetHold", holdAttr(holdID))
+	hold, err := r.next.GetHold(ctx, holdID)
+	endSpan(span, err)
+
+	return hold, err
+}
+
+func (r *HoldRepository) ListHoldsByEvent(ctx context.Context, eventID uuid.UUID, status *domain.HoldStatus) ([]domain.InventoryHold, error) {
+	ctx, span := startSpan(ctx, "HoldRepository.ListHoldsByEvent", eventAttr(eventID))
+	holds, err := r.next.ListHoldsByEvent(ctx, eventID, status)
+	span.SetAttributes(attribute.Int("hold.count", len(holds)))
+	endSpan(span, err)
+
+	return holds, err
+}
+
+func (r *HoldRepository) ReleaseHold(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) error {
+	ctx, span := startSpan(ctx, "HoldRepository.ReleaseHold", holdAttr(holdID))
+	err := r.next.ReleaseHold(ctx, holdID, actorID, reason)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *HoldRepository) ConvertHold(ctx context.Context, holdID uuid.UUID, booking *domain.Booking, actorID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "HoldRepository.ConvertHold", holdAttr(holdID), bookingAttr(booking.ID))
+	err := r.next.ConvertHold(ctx, holdID, booking, actorID)
+	endSpan(span, err)
+
+	return err
+}
+
+func (r *HoldRepository) GetDueHolds(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	ctx, span := startSpan(ctx, "HoldRepository.GetDueHolds")
+	ids, err := r.next.GetDueHolds(ctx, now)
+	span.SetAttributes(attribute.Int("hold.count", len(ids)))
+	endSpan(span, err)
+
+	return ids, err
+}
@@ -0,0 +1,31 @@
+// Package tracing adds OpenTelemetry spans around the driven adapters (the
+// repositories and Redis) and the HTTP and gRPC servers. Spans go to the global tracer
+// provider, so nothing is recorded until telemetry.SetupTracing installs one.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/srgjo27/scalable_ticket/internal/adapter/tracing"
+
+var tracer = otel.Tracer(instrumentationName)
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,76 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/adapter/tracing"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recorder collects every span ended in this package's tests. The global
+// provider can only be installed once, so each test resets the recorder
+// instead.
+var recorder = tracetest.NewSpanRecorder()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	os.Exit(m.Run())
+}
+
+func TestMiddleware_ContinuesTraceAndNamesSpanAfterRoute(t *testing.T) {
+	recorder.Reset()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bookings/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/bookings/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	tracing.Middleware(mux).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, "GET /bookings/{id}", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestSeatRepository_RecordsFailedLock(t *testing.T) {
+	recorder.Reset()
+
+	seatRepo := mocks.NewSeatRepository(t)
+	seatRepo.On("LockSeat", mock.Anything, mock.Anything, mock.Anything, mock.Anything, 3).
+		Return(errors.New("optimistic lock failed"))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	err := tracing.NewSeatRepository(seatRepo).LockSeat(ctx, uuid.New(), uuid.New(), uuid.New(), 3)
+	parent.End()
+
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	lock := spans[0]
+	assert.Equal(t, "SeatRepository.LockSeat", lock.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), lock.Parent().SpanID())
+	assert.Equal(t, codes.Error, lock.Status().Code)
+	assert.Equal(t, "optimistic lock failed", lock.Status().Description)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditReasonSeatLocked     = "seat locked for booking"
+	AuditReasonLockRolledBack = "seat lock rolled back"
+	AuditReasonConfirmed      = "booking confirmed"
+	AuditReasonCancelled      = "booking cancelled"
+	AuditReasonExpired        = "booking expired"
+
+	AuditReasonHeld             = "seat held"
+	AuditReasonHoldReleased     = "hold released"
+	AuditReasonHoldAutoReleased = "hold auto-released"
+	AuditReasonComplimentary    = "hold converted to complimentary booking"
+)
+
+// SeatAuditLog is a single seat state transition. ChangedByUserID is nil when
+// the change was made by the system (e.g. the expiry worker). HoldID is set
+// when the change came from an inventory hold.
+type SeatAuditLog struct {
+	ID              uuid.UUID
+	SeatID          uuid.UUID
+	OldStatus       SeatStatus
+	NewStatus       SeatStatus
+	ChangedByUserID *uuid.UUID
+	BookingID       *uuid.UUID
+	HoldID          *uuid.UUID
+	ChangedAt       time.Time
+	Reason          string
+}
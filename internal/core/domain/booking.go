@@ -25,6 +25,7 @@ type Booking struct {
 	ExpiresAt   time.Time
 	ConfirmedAt *time.Time
 	Items       []BookingItem
+	Payment     *Payment
 }
 
 type BookingItem struct {
@@ -32,4 +33,36 @@ type BookingItem struct {
 	BookingID      uuid.UUID
 	SeatID         uuid.UUID
 	PriceAtBooking float64
+	Seat           *Seat
+}
+
+// BookingCursor marks the last booking of a page. Bookings are listed newest
+// first, so the next page starts strictly after (CreatedAt, ID).
+type BookingCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type BookingFilter struct {
+	UserID  uuid.UUID
+	Status  *BookingStatus
+	EventID *uuid.UUID
+	After   *BookingCursor
+	Limit   int
+}
+
+func (b *Booking) HoldRemaining(now time.Time) time.Duration {
+	if b.Status != BookingPending || !now.Before(b.ExpiresAt) {
+		return 0
+	}
+
+	return b.ExpiresAt.Sub(now)
+}
+
+func (b *Booking) PaymentStatus() string {
+	if b.Payment == nil {
+		return PaymentStatusUnpaid
+	}
+
+	return b.Payment.Status
 }
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationMode string
+
+const (
+	// ReservationDatabase locks seats with optimistic locking in Postgres.
+	ReservationDatabase ReservationMode = "DATABASE"
+	// ReservationRedis claims seats atomically in Redis first and writes the
+	// booking to Postgres asynchronously. Meant for mega on-sales.
+	ReservationRedis ReservationMode = "REDIS"
+)
+
+type Event struct {
+	ID              uuid.UUID
+	VenueID         uuid.UUID
+	Name            string
+	StartTime       time.Time
+	EndTime         time.Time
+	IsActive        bool
+	ReservationMode ReservationMode
+}
+
+// SeatHold is a seat claimed in the reservation store on behalf of a booking
+// that may not have reached Postgres yet.
+type SeatHold struct {
+	SeatID    uuid.UUID
+	BookingID uuid.UUID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (h SeatHold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive    HoldStatus = "ACTIVE"
+	HoldReleased  HoldStatus = "RELEASED"
+	HoldConverted HoldStatus = "CONVERTED"
+)
+
+// InventoryHold takes a set of seats off sale for an organizer, e.g. camera
+// positions, sponsor allocations or artist guests. Its seats are UNAVAILABLE
+// while it is ACTIVE. Unlike a SeatHold it is not tied to a booking and only
+// ends when released or converted. A hold without ReleaseAt is a kill: it
+// stays until someone releases it.
+type InventoryHold struct {
+	ID        uuid.UUID
+	EventID   uuid.UUID
+	Name      string
+	Reason    string
+	Owner     string
+	SeatIDs   []uuid.UUID
+	Status    HoldStatus
+	ReleaseAt *time.Time
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+	ClosedBy  *uuid.UUID
+	ClosedAt  *time.Time
+	BookingID *uuid.UUID
+}
+
+func (h *InventoryHold) IsDue(now time.Time) bool {
+	return h.Status == HoldActive && h.ReleaseAt != nil && !now.Before(*h.ReleaseAt)
+}
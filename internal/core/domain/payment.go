@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentStatusUnpaid  = "UNPAID"
+	PaymentStatusSuccess = "SUCCESS"
+
+	// PaymentMethodComplimentary marks the zero-amount payment recorded for a
+	// booking given away from an inventory hold.
+	PaymentMethodComplimentary = "COMPLIMENTARY"
+)
+
+type Payment struct {
+	ID                    uuid.UUID
+	BookingID             uuid.UUID
+	Amount                float64
+	PaymentMethod         string
+	ProviderTransactionID string
+	Status                string
+	PaidAt                time.Time
+}
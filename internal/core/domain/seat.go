@@ -9,10 +9,11 @@ import (
 type SeatStatus string
 
 const (
-	SeatAvailable SeatStatus = "AVAILABLE"
-	SeatLocked    SeatStatus = "LOCKED"
-	SeatBooked    SeatStatus = "BOOKED"
-	SeatSold      SeatStatus = "SOLD"
+	SeatAvailable   SeatStatus = "AVAILABLE"
+	SeatLocked      SeatStatus = "LOCKED"
+	SeatBooked      SeatStatus = "BOOKED"
+	SeatSold        SeatStatus = "SOLD"
+	SeatUnavailable SeatStatus = "UNAVAILABLE"
 )
 
 type Seat struct {
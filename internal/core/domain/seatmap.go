@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type PricingTier struct {
+	ID      uuid.UUID
+	EventID uuid.UUID
+	Name    string
+	Price   float64
+}
+
+type SeatMap struct {
+	EventID  uuid.UUID
+	Tiers    []PricingTier
+	Sections []SeatMapSection
+}
+
+type SeatMapSection struct {
+	Name string
+	Rows []SeatMapRow
+}
+
+type SeatMapRow struct {
+	Name  string
+	Seats []Seat
+}
+
+// SortSeats orders seats by section, row and seat number. Numbers are
+// compared naturally, so row "10" sorts after row "9".
+func SortSeats(seats []Seat) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		a, b := seats[i], seats[j]
+		if a.Section != b.Section {
+			return naturalLess(a.Section, b.Section)
+		}
+
+		if a.RowNumber != b.RowNumber {
+			return naturalLess(a.RowNumber, b.RowNumber)
+		}
+
+		return naturalLess(a.SeatNumber, b.SeatNumber)
+	})
+}
+
+// BuildSeatMap groups seats by section and row in SortSeats order.
+func BuildSeatMap(eventID uuid.UUID, seats []Seat, tiers []PricingTier) SeatMap {
+	sorted := make([]Seat, len(seats))
+	copy(sorted, seats)
+	SortSeats(sorted)
+
+	seatMap := SeatMap{EventID: eventID, Tiers: tiers}
+
+	for _, seat := range sorted {
+		if n := len(seatMap.Sections); n == 0 || seatMap.Sections[n-1].Name != seat.Section {
+			seatMap.Sections = append(seatMap.Sections, SeatMapSection{Name: seat.Section})
+		}
+
+		section := &seatMap.Sections[len(seatMap.Sections)-1]
+
+		if n := len(section.Rows); n == 0 || section.Rows[n-1].Name != seat.RowNumber {
+			section.Rows = append(section.Rows, SeatMapRow{Name: seat.RowNumber})
+		}
+
+		row := &section.Rows[len(section.Rows)-1]
+		row.Seats = append(row.Seats, seat)
+	}
+
+	return seatMap
+}
+
+func naturalLess(a, b string) bool {
+	ai, aErr := strconv.Atoi(a)
+	bi, bErr := strconv.Atoi(b)
+
+	if aErr == nil && bErr == nil {
+		return ai < bi
+	}
+
+	return a < b
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache is a plain key/value cache. Get returns ErrCacheMiss for unknown or
+// expired keys.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// SeatCache holds the available seats of each event and is updated in place
+// as seats change state. AvailableSeats reports ok=false when the event has
+// not been loaded yet, which is different from an event with no seats left.
+//
+// Every change to an event's seats bumps its generation. A rebuild reads
+// SeatGeneration before loading seats from the database and passes it to
+// ReplaceAvailableSeats, which drops the write (replaced=false) when a change
+// landed in between, so a stale snapshot never overwrites it.
+type SeatCache interface {
+	AvailableSeats(ctx context.Context, eventID uuid.UUID) (seats []domain.Seat, ok bool)
+	SeatGeneration(ctx context.Context, eventID uuid.UUID) (int64, error)
+	ReplaceAvailableSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat, generation int64) (replaced bool, err error)
+	RemoveSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) error
+	PutSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat) error
+	LockRebuild(ctx context.Context, eventID uuid.UUID) (acquired bool, release func())
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Metrics records booking behaviour for monitoring. Reasons are short
+// snake_case labels such as "seat_unavailable" or "hold_expired"; eventID is
+// uuid.Nil when the request did not name a valid event.
+type Metrics interface {
+	BookingCreated(eventID uuid.UUID)
+	BookingFailed(eventID uuid.UUID, reason string)
+	BookingExpired(reason string)
+	// BookingDuration observes one CreateBooking call; outcome is "created"
+	// or "failed".
+	BookingDuration(outcome string, elapsed time.Duration)
+	LockConflict(eventID uuid.UUID)
+	SeatCacheLookup(hit bool)
+	// SeatCacheEvent counts seat cache rebuild activity such as "coalesced"
+	// or "stale_rebuilds".
+	SeatCacheEvent(event string)
+	// ReservationEvent counts Redis reservation activity such as "reserved"
+	// or "reconcile_stale".
+	ReservationEvent(event string)
+	// CleanupBacklog reports how many expired bookings were waiting when the
+	// last cleanup run started, not just the batch it handled.
+	CleanupBacklog(size int)
+}
@@ -34,6 +34,34 @@ func (_m *BookingRepository) CancelBooking(ctx context.Context, bookingID uuid.U
 	return r0
 }
 
+// CountExpiredBookings provides a mock function with given fields: ctx
+func (_m *BookingRepository) CountExpiredBookings(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountExpiredBookings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateBooking provides a mock function with given fields: ctx, booking
 func (_m *BookingRepository) CreateBooking(ctx context.Context, booking *domain.Booking) error {
 	ret := _m.Called(ctx, booking)
@@ -52,6 +80,36 @@ func (_m *BookingRepository) CreateBooking(ctx context.Context, booking *domain.
 	return r0
 }
 
+// GetByID provides a mock function with given fields: ctx, bookingID
+func (_m *BookingRepository) GetByID(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	ret := _m.Called(ctx, bookingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Booking, error)); ok {
+		return rf(ctx, bookingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Booking); ok {
+		r0 = rf(ctx, bookingID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetExpiredBookings provides a mock function with given fields: ctx
 func (_m *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUID, error) {
 	ret := _m.Called(ctx)
@@ -82,6 +140,36 @@ func (_m *BookingRepository) GetExpiredBookings(ctx context.Context) ([]uuid.UUI
 	return r0, r1
 }
 
+// ListByUser provides a mock function with given fields: ctx, filter
+func (_m *BookingRepository) ListByUser(ctx context.Context, filter domain.BookingFilter) ([]domain.Booking, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookingFilter) ([]domain.Booking, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookingFilter) []domain.Booking); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BookingFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStatus provides a mock function with given fields: ctx, bookingID, status
 func (_m *BookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUID, status domain.BookingStatus) error {
 	ret := _m.Called(ctx, bookingID, status)
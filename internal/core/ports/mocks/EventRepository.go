@@ -0,0 +1,109 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/srgjo27/scalable_ticket/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// EventRepository is an autogenerated mock type for the EventRepository type
+type EventRepository struct {
+	mock.Mock
+}
+
+// GetByID provides a mock function with given fields: ctx, eventID
+func (_m *EventRepository) GetByID(ctx context.Context, eventID uuid.UUID) (*domain.Event, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Event, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Event); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByReservationMode provides a mock function with given fields: ctx, mode
+func (_m *EventRepository) ListByReservationMode(ctx context.Context, mode domain.ReservationMode) ([]domain.Event, error) {
+	ret := _m.Called(ctx, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByReservationMode")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReservationMode) ([]domain.Event, error)); ok {
+		return rf(ctx, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReservationMode) []domain.Event); ok {
+		r0 = rf(ctx, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReservationMode) error); ok {
+		r1 = rf(ctx, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetReservationMode provides a mock function with given fields: ctx, eventID, mode
+func (_m *EventRepository) SetReservationMode(ctx context.Context, eventID uuid.UUID, mode domain.ReservationMode) error {
+	ret := _m.Called(ctx, eventID, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReservationMode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReservationMode) error); ok {
+		r0 = rf(ctx, eventID, mode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEventRepository creates a new instance of EventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventRepository {
+	mock := &EventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,177 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/srgjo27/scalable_ticket/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// HoldRepository is an autogenerated mock type for the HoldRepository type
+type HoldRepository struct {
+	mock.Mock
+}
+
+// ConvertHold provides a mock function with given fields: ctx, holdID, booking, actorID
+func (_m *HoldRepository) ConvertHold(ctx context.Context, holdID uuid.UUID, booking *domain.Booking, actorID uuid.UUID) error {
+	ret := _m.Called(ctx, holdID, booking, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConvertHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.Booking, uuid.UUID) error); ok {
+		r0 = rf(ctx, holdID, booking, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateHold provides a mock function with given fields: ctx, hold
+func (_m *HoldRepository) CreateHold(ctx context.Context, hold *domain.InventoryHold) error {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.InventoryHold) error); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDueHolds provides a mock function with given fields: ctx, now
+func (_m *HoldRepository) GetDueHolds(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueHolds")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHold provides a mock function with given fields: ctx, holdID
+func (_m *HoldRepository) GetHold(ctx context.Context, holdID uuid.UUID) (*domain.InventoryHold, error) {
+	ret := _m.Called(ctx, holdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHold")
+	}
+
+	var r0 *domain.InventoryHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.InventoryHold, error)); ok {
+		return rf(ctx, holdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.InventoryHold); ok {
+		r0 = rf(ctx, holdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.InventoryHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHoldsByEvent provides a mock function with given fields: ctx, eventID, status
+func (_m *HoldRepository) ListHoldsByEvent(ctx context.Context, eventID uuid.UUID, status *domain.HoldStatus) ([]domain.InventoryHold, error) {
+	ret := _m.Called(ctx, eventID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHoldsByEvent")
+	}
+
+	var r0 []domain.InventoryHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.HoldStatus) ([]domain.InventoryHold, error)); ok {
+		return rf(ctx, eventID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.HoldStatus) []domain.InventoryHold); ok {
+		r0 = rf(ctx, eventID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.InventoryHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.HoldStatus) error); ok {
+		r1 = rf(ctx, eventID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseHold provides a mock function with given fields: ctx, holdID, actorID, reason
+func (_m *HoldRepository) ReleaseHold(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) error {
+	ret := _m.Called(ctx, holdID, actorID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, string) error); ok {
+		r0 = rf(ctx, holdID, actorID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHoldRepository creates a new instance of HoldRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHoldRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HoldRepository {
+	mock := &HoldRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
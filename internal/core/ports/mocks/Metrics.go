@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// Metrics is an autogenerated mock type for the Metrics type
+type Metrics struct {
+	mock.Mock
+}
+
+// BookingCreated provides a mock function with given fields: eventID
+func (_m *Metrics) BookingCreated(eventID uuid.UUID) {
+	_m.Called(eventID)
+}
+
+// BookingDuration provides a mock function with given fields: outcome, elapsed
+func (_m *Metrics) BookingDuration(outcome string, elapsed time.Duration) {
+	_m.Called(outcome, elapsed)
+}
+
+// BookingExpired provides a mock function with given fields: reason
+func (_m *Metrics) BookingExpired(reason string) {
+	_m.Called(reason)
+}
+
+// BookingFailed provides a mock function with given fields: eventID, reason
+func (_m *Metrics) BookingFailed(eventID uuid.UUID, reason string) {
+	_m.Called(eventID, reason)
+}
+
+// CleanupBacklog provides a mock function with given fields: size
+func (_m *Metrics) CleanupBacklog(size int) {
+	_m.Called(size)
+}
+
+// LockConflict provides a mock function with given fields: eventID
+func (_m *Metrics) LockConflict(eventID uuid.UUID) {
+	_m.Called(eventID)
+}
+
+// ReservationEvent provides a mock function with given fields: event
+func (_m *Metrics) ReservationEvent(event string) {
+	_m.Called(event)
+}
+
+// SeatCacheEvent provides a mock function with given fields: event
+func (_m *Metrics) SeatCacheEvent(event string) {
+	_m.Called(event)
+}
+
+// SeatCacheLookup provides a mock function with given fields: hit
+func (_m *Metrics) SeatCacheLookup(hit bool) {
+	_m.Called(hit)
+}
+
+// NewMetrics creates a new instance of Metrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Metrics {
+	mock := &Metrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	ports "github.com/srgjo27/scalable_ticket/internal/core/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateLimiter is an autogenerated mock type for the RateLimiter type
+type RateLimiter struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, key, limit
+func (_m *RateLimiter) Allow(ctx context.Context, key string, limit ports.RateLimit) (ports.RateLimitResult, error) {
+	ret := _m.Called(ctx, key, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allow")
+	}
+
+	var r0 ports.RateLimitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ports.RateLimit) (ports.RateLimitResult, error)); ok {
+		return rf(ctx, key, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ports.RateLimit) ports.RateLimitResult); ok {
+		r0 = rf(ctx, key, limit)
+	} else {
+		r0 = ret.Get(0).(ports.RateLimitResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ports.RateLimit) error); ok {
+		r1 = rf(ctx, key, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateLimiter creates a new instance of RateLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimiter {
+	mock := &RateLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/srgjo27/scalable_ticket/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// SeatAuditRepository is an autogenerated mock type for the SeatAuditRepository type
+type SeatAuditRepository struct {
+	mock.Mock
+}
+
+// ListBySeat provides a mock function with given fields: ctx, seatID, limit
+func (_m *SeatAuditRepository) ListBySeat(ctx context.Context, seatID uuid.UUID, limit int) ([]domain.SeatAuditLog, error) {
+	ret := _m.Called(ctx, seatID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySeat")
+	}
+
+	var r0 []domain.SeatAuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]domain.SeatAuditLog, error)); ok {
+		return rf(ctx, seatID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []domain.SeatAuditLog); ok {
+		r0 = rf(ctx, seatID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SeatAuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, seatID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeatAuditRepository creates a new instance of SeatAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeatAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeatAuditRepository {
+	mock := &SeatAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/srgjo27/scalable_ticket/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// SeatCache is an autogenerated mock type for the SeatCache type
+type SeatCache struct {
+	mock.Mock
+}
+
+// AvailableSeats provides a mock function with given fields: ctx, eventID
+func (_m *SeatCache) AvailableSeats(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, bool) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AvailableSeats")
+	}
+
+	var r0 []domain.Seat
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Seat, bool)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Seat); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Seat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) bool); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// LockRebuild provides a mock function with given fields: ctx, eventID
+func (_m *SeatCache) LockRebuild(ctx context.Context, eventID uuid.UUID) (bool, func()) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockRebuild")
+	}
+
+	var r0 bool
+	var r1 func()
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, func())); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) func()); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
+
+// PutSeats provides a mock function with given fields: ctx, eventID, seats
+func (_m *SeatCache) PutSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat) error {
+	ret := _m.Called(ctx, eventID, seats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.Seat) error); ok {
+		r0 = rf(ctx, eventID, seats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveSeats provides a mock function with given fields: ctx, eventID, seatIDs
+func (_m *SeatCache) RemoveSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, eventID, seatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(ctx, eventID, seatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceAvailableSeats provides a mock function with given fields: ctx, eventID, seats, generation
+func (_m *SeatCache) ReplaceAvailableSeats(ctx context.Context, eventID uuid.UUID, seats []domain.Seat, generation int64) (bool, error) {
+	ret := _m.Called(ctx, eventID, seats, generation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAvailableSeats")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.Seat, int64) (bool, error)); ok {
+		return rf(ctx, eventID, seats, generation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.Seat, int64) bool); ok {
+		r0 = rf(ctx, eventID, seats, generation)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []domain.Seat, int64) error); ok {
+		r1 = rf(ctx, eventID, seats, generation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SeatGeneration provides a mock function with given fields: ctx, eventID
+func (_m *SeatCache) SeatGeneration(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SeatGeneration")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeatCache creates a new instance of SeatCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeatCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeatCache {
+	mock := &SeatCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -76,17 +76,77 @@ func (_m *SeatRepository) GetByID(ctx context.Context, seatID uuid.UUID) (*domai
 	return r0, r1
 }
 
-// LockSeat provides a mock function with given fields: ctx, seatID, bookingID, currentVersion
-func (_m *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, currentVersion int) error {
-	ret := _m.Called(ctx, seatID, bookingID, currentVersion)
+// GetSeatsByEvent provides a mock function with given fields: ctx, eventID
+func (_m *SeatRepository) GetSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeatsByEvent")
+	}
+
+	var r0 []domain.Seat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Seat, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Seat); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Seat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTiersByEvent provides a mock function with given fields: ctx, eventID
+func (_m *SeatRepository) GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.PricingTier, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTiersByEvent")
+	}
+
+	var r0 []domain.PricingTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.PricingTier, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.PricingTier); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PricingTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockSeat provides a mock function with given fields: ctx, seatID, bookingID, actorID, currentVersion
+func (_m *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID, currentVersion int) error {
+	ret := _m.Called(ctx, seatID, bookingID, actorID, currentVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LockSeat")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int) error); ok {
-		r0 = rf(ctx, seatID, bookingID, currentVersion)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, seatID, bookingID, actorID, currentVersion)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -94,17 +154,17 @@ func (_m *SeatRepository) LockSeat(ctx context.Context, seatID uuid.UUID, bookin
 	return r0
 }
 
-// UnlockSeat provides a mock function with given fields: ctx, seatID
-func (_m *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID) error {
-	ret := _m.Called(ctx, seatID)
+// UnlockSeat provides a mock function with given fields: ctx, seatID, bookingID, actorID
+func (_m *SeatRepository) UnlockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) error {
+	ret := _m.Called(ctx, seatID, bookingID, actorID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UnlockSeat")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, seatID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, seatID, bookingID, actorID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/srgjo27/scalable_ticket/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// SeatReservationStore is an autogenerated mock type for the SeatReservationStore type
+type SeatReservationStore struct {
+	mock.Mock
+}
+
+// Holds provides a mock function with given fields: ctx, eventID
+func (_m *SeatReservationStore) Holds(ctx context.Context, eventID uuid.UUID) ([]domain.SeatHold, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Holds")
+	}
+
+	var r0 []domain.SeatHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.SeatHold, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.SeatHold); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SeatHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: ctx, eventID, bookingID, seatIDs
+func (_m *SeatReservationStore) Release(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, eventID, bookingID, seatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(ctx, eventID, bookingID, seatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, eventID, bookingID, seatIDs, ttl
+func (_m *SeatReservationStore) Reserve(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID, ttl time.Duration) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, eventID, bookingID, seatIDs, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID, time.Duration) ([]uuid.UUID, error)); ok {
+		return rf(ctx, eventID, bookingID, seatIDs, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID, time.Duration) []uuid.UUID); ok {
+		r0 = rf(ctx, eventID, bookingID, seatIDs, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, []uuid.UUID, time.Duration) error); ok {
+		r1 = rf(ctx, eventID, bookingID, seatIDs, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeatReservationStore creates a new instance of SeatReservationStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeatReservationStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeatReservationStore {
+	mock := &SeatReservationStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit allows Limit requests per Window. Up to Limit requests may
+// arrive at once; after that they are spaced Window/Limit apart.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitResult is the state of a key after one request was counted.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next request would be allowed. It is
+	// zero when Allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the key is back to its full allowance.
+	Reset time.Duration
+}
+
+// RateLimiter counts requests per key. A limiter shared between replicas,
+// such as the Redis one, enforces the limit across the cluster.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
@@ -2,21 +2,69 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/srgjo27/scalable_ticket/internal/core/domain"
 )
 
+// ErrSeatNotFound means no seat has the given ID.
+var ErrSeatNotFound = errors.New("seat not found")
+
+// ErrBookingNotPending means a booking was confirmed, cancelled or expired
+// before the change could be made.
+var ErrBookingNotPending = errors.New("booking is not pending")
+
+// ErrHoldNotActive means a hold was released or converted before the change
+// could be made.
+var ErrHoldNotActive = errors.New("hold is not active")
+
 type SeatRepository interface {
+	// GetByID fails with ErrSeatNotFound for an unknown seat.
 	GetByID(ctx context.Context, seatID uuid.UUID) (*domain.Seat, error)
 	GetAvailableSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error)
-	LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, currentVersion int) error
-	UnlockSeat(ctx context.Context, seatID uuid.UUID) error
+	GetSeatsByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error)
+	GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.PricingTier, error)
+	LockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID, currentVersion int) error
+	// UnlockSeat rolls back a lock taken by bookingID. It leaves the seat
+	// alone when it is no longer LOCKED by that booking.
+	UnlockSeat(ctx context.Context, seatID uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) error
 }
 
 type BookingRepository interface {
 	CreateBooking(ctx context.Context, booking *domain.Booking) error
 	UpdateStatus(ctx context.Context, bookingID uuid.UUID, status domain.BookingStatus) error
+	// GetExpiredBookings returns one batch of PENDING bookings past their
+	// expiry; CountExpiredBookings counts all of them.
 	GetExpiredBookings(ctx context.Context) ([]uuid.UUID, error)
+	CountExpiredBookings(ctx context.Context) (int, error)
+	// CancelBooking expires a PENDING booking and releases its locked seats.
+	// It fails with ErrBookingNotPending once the booking has moved on.
 	CancelBooking(ctx context.Context, bookingID uuid.UUID) error
+	GetByID(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error)
+	ListByUser(ctx context.Context, filter domain.BookingFilter) ([]domain.Booking, error)
+}
+
+type SeatAuditRepository interface {
+	ListBySeat(ctx context.Context, seatID uuid.UUID, limit int) ([]domain.SeatAuditLog, error)
+}
+
+// HoldRepository stores inventory holds and moves their seats. Every write
+// changes the hold and its seats in one transaction and audits each seat.
+type HoldRepository interface {
+	// CreateHold takes every seat off sale or none: each must belong to the
+	// hold's event and be AVAILABLE.
+	CreateHold(ctx context.Context, hold *domain.InventoryHold) error
+	GetHold(ctx context.Context, holdID uuid.UUID) (*domain.InventoryHold, error)
+	ListHoldsByEvent(ctx context.Context, eventID uuid.UUID, status *domain.HoldStatus) ([]domain.InventoryHold, error)
+	// ReleaseHold puts an ACTIVE hold's seats back on sale. actorID is nil
+	// for automatic releases. ReleaseHold and ConvertHold fail with
+	// ErrHoldNotActive once the hold is closed.
+	ReleaseHold(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) error
+	// ConvertHold books an ACTIVE hold's seats with booking, which must be
+	// CONFIRMED and list exactly the hold's seats.
+	ConvertHold(ctx context.Context, holdID uuid.UUID, booking *domain.Booking, actorID uuid.UUID) error
+	// GetDueHolds returns ACTIVE holds whose release time has passed.
+	GetDueHolds(ctx context.Context, now time.Time) ([]uuid.UUID, error)
 }
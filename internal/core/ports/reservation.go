@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+// ErrReservationNotReady means the store has no availability data for the
+// event yet; the caller should load the seat cache and retry.
+var ErrReservationNotReady = errors.New("reservation store not loaded for event")
+
+type EventRepository interface {
+	GetByID(ctx context.Context, eventID uuid.UUID) (*domain.Event, error)
+	ListByReservationMode(ctx context.Context, mode domain.ReservationMode) ([]domain.Event, error)
+	SetReservationMode(ctx context.Context, eventID uuid.UUID, mode domain.ReservationMode) error
+}
+
+// SeatReservationStore claims seats outside Postgres. Reserve is all-or-none:
+// it returns the seats that could not be claimed and claims nothing if any
+// seat conflicts.
+type SeatReservationStore interface {
+	Reserve(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID, ttl time.Duration) (conflicts []uuid.UUID, err error)
+	Release(ctx context.Context, eventID uuid.UUID, bookingID uuid.UUID, seatIDs []uuid.UUID) error
+	Holds(ctx context.Context, eventID uuid.UUID) ([]domain.SeatHold, error)
+}
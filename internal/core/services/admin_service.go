@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+)
+
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 500
+)
+
+type SeatHistoryEntry struct {
+	OldStatus       string  `json:"old_status"`
+	NewStatus       string  `json:"new_status"`
+	ChangedByUserID *string `json:"changed_by_user_id"`
+	BookingID       *string `json:"booking_id"`
+	HoldID          *string `json:"hold_id"`
+	Reason          string  `json:"reason"`
+	ChangedAt       string  `json:"changed_at"`
+}
+
+type AdminService struct {
+	auditRepo ports.SeatAuditRepository
+	eventRepo ports.EventRepository
+}
+
+func NewAdminService(auditRepo ports.SeatAuditRepository, eventRepo ports.EventRepository) *AdminService {
+	return &AdminService{
+		auditRepo: auditRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *AdminService) GetSeatHistory(ctx context.Context, seatIDStr string, limit int) ([]SeatHistoryEntry, error) {
+	seatID, err := uuid.Parse(seatIDStr)
+	if err != nil {
+		return nil, errors.New("invalid seat id")
+	}
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	logs, err := s.auditRepo.ListBySeat(ctx, seatID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]SeatHistoryEntry, 0, len(logs))
+	for _, log := range logs {
+		entries = append(entries, SeatHistoryEntry{
+			OldStatus:       string(log.OldStatus),
+			NewStatus:       string(log.NewStatus),
+			ChangedByUserID: uuidString(log.ChangedByUserID),
+			BookingID:       uuidString(log.BookingID),
+			HoldID:          uuidString(log.HoldID),
+			Reason:          log.Reason,
+			ChangedAt:       log.ChangedAt.Format(time.RFC3339),
+		})
+	}
+
+	return entries, nil
+}
+
+// SetReservationMode switches how an event's seats are reserved. Replicas pick
+// up the change within a few seconds.
+func (s *AdminService) SetReservationMode(ctx context.Context, eventIDStr string, modeStr string) error {
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return errors.New("invalid event id")
+	}
+
+	mode := domain.ReservationMode(strings.ToUpper(modeStr))
+	if mode != domain.ReservationDatabase && mode != domain.ReservationRedis {
+		return errors.New("invalid reservation mode")
+	}
+
+	return s.eventRepo.SetReservationMode(ctx, eventID, mode)
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	str := id.String()
+	return &str
+}
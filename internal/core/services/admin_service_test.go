@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSeatHistory_Success(t *testing.T) {
+	mockAuditRepo := mocks.NewSeatAuditRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	service := services.NewAdminService(mockAuditRepo, mockEventRepo)
+
+	ctx := context.Background()
+	seatID := uuid.New()
+	bookingID := uuid.New()
+	userID := uuid.New()
+
+	logs := []domain.SeatAuditLog{
+		{
+			ID:        uuid.New(),
+			SeatID:    seatID,
+			OldStatus: domain.SeatLocked,
+			NewStatus: domain.SeatAvailable,
+			BookingID: &bookingID,
+			ChangedAt: time.Now(),
+			Reason:    domain.AuditReasonExpired,
+		},
+		{
+			ID:              uuid.New(),
+			SeatID:          seatID,
+			OldStatus:       domain.SeatAvailable,
+			NewStatus:       domain.SeatLocked,
+			ChangedByUserID: &userID,
+			BookingID:       &bookingID,
+			ChangedAt:       time.Now().Add(-10 * time.Minute),
+			Reason:          domain.AuditReasonSeatLocked,
+		},
+	}
+
+	mockAuditRepo.On("ListBySeat", ctx, seatID, 100).Return(logs, nil)
+
+	history, err := service.GetSeatHistory(ctx, seatID.String(), 0)
+
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Nil(t, history[0].ChangedByUserID)
+		assert.Equal(t, "AVAILABLE", history[0].NewStatus)
+		assert.Equal(t, userID.String(), *history[1].ChangedByUserID)
+		assert.Equal(t, bookingID.String(), *history[1].BookingID)
+	}
+}
+
+func TestGetSeatHistory_InvalidSeatID(t *testing.T) {
+	mockAuditRepo := mocks.NewSeatAuditRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	service := services.NewAdminService(mockAuditRepo, mockEventRepo)
+
+	history, err := service.GetSeatHistory(context.Background(), "not-a-uuid", 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, history)
+	assert.Contains(t, err.Error(), "invalid seat id")
+}
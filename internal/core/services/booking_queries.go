@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+)
+
+const (
+	defaultBookingPageSize = 20
+	maxBookingPageSize     = 100
+)
+
+type BookingItemResponse struct {
+	SeatID     string  `json:"seat_id"`
+	Section    string  `json:"section"`
+	RowNumber  string  `json:"row_number"`
+	SeatNumber string  `json:"seat_number"`
+	Price      float64 `json:"price"`
+}
+
+type BookingDetailResponse struct {
+	BookingID            string                `json:"booking_id"`
+	UserID               string                `json:"user_id"`
+	EventID              string                `json:"event_id"`
+	Status               string                `json:"status"`
+	TotalAmount          float64               `json:"total_amount"`
+	PaymentStatus        string                `json:"payment_status"`
+	CreatedAt            string                `json:"created_at"`
+	ExpiresAt            string                `json:"expires_at"`
+	ConfirmedAt          *string               `json:"confirmed_at"`
+	HoldRemainingSeconds int64                 `json:"hold_remaining_seconds"`
+	Items                []BookingItemResponse `json:"items"`
+}
+
+type ListBookingsRequest struct {
+	UserID  string
+	Status  string
+	EventID string
+	Cursor  string
+	Limit   int
+}
+
+type ListBookingsResponse struct {
+	Bookings   []BookingDetailResponse `json:"bookings"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// GetBooking returns a booking owned by userIDStr. Bookings of other users are
+// reported as not found so their IDs can't be probed.
+func (s *BookingService) GetBooking(ctx context.Context, userIDStr string, bookingIDStr string) (*BookingDetailResponse, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+
+	bookingID, err := uuid.Parse(bookingIDStr)
+	if err != nil {
+		return nil, errors.New("invalid booking id")
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.UserID != userID {
+		return nil, errors.New("booking not found")
+	}
+
+	resp := toBookingDetail(booking, time.Now())
+	return &resp, nil
+}
+
+func (s *BookingService) ListUserBookings(ctx context.Context, req ListBookingsRequest) (*ListBookingsResponse, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+
+	filter := domain.BookingFilter{
+		UserID: userID,
+		Limit:  req.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultBookingPageSize
+	}
+
+	if filter.Limit > maxBookingPageSize {
+		filter.Limit = maxBookingPageSize
+	}
+
+	if req.Status != "" {
+		status := domain.BookingStatus(strings.ToUpper(req.Status))
+		switch status {
+		case domain.BookingPending, domain.BookingConfirmed, domain.BookingExpired, domain.BookingCancelled:
+			filter.Status = &status
+		default:
+			return nil, errors.New("invalid status filter")
+		}
+	}
+
+	if req.EventID != "" {
+		eventID, err := uuid.Parse(req.EventID)
+		if err != nil {
+			return nil, errors.New("invalid event id")
+		}
+
+		filter.EventID = &eventID
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeBookingCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.After = cursor
+	}
+
+	// Fetch one extra row to learn whether another page exists.
+	pageSize := filter.Limit
+	filter.Limit++
+
+	bookings, err := s.bookingRepo.ListByUser(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListBookingsResponse{Bookings: []BookingDetailResponse{}}
+
+	if len(bookings) > pageSize {
+		bookings = bookings[:pageSize]
+		last := bookings[pageSize-1]
+		resp.NextCursor = encodeBookingCursor(domain.BookingCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	now := time.Now()
+	for i := range bookings {
+		resp.Bookings = append(resp.Bookings, toBookingDetail(&bookings[i], now))
+	}
+
+	return resp, nil
+}
+
+func toBookingDetail(booking *domain.Booking, now time.Time) BookingDetailResponse {
+	resp := BookingDetailResponse{
+		BookingID:            booking.ID.String(),
+		UserID:               booking.UserID.String(),
+		EventID:              booking.EventID.String(),
+		Status:               string(booking.Status),
+		TotalAmount:          booking.TotalAmount,
+		PaymentStatus:        booking.PaymentStatus(),
+		CreatedAt:            booking.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:            booking.ExpiresAt.Format(time.RFC3339),
+		HoldRemainingSeconds: int64(booking.HoldRemaining(now).Seconds()),
+		Items:                make([]BookingItemResponse, 0, len(booking.Items)),
+	}
+
+	if booking.ConfirmedAt != nil {
+		confirmedAt := booking.ConfirmedAt.Format(time.RFC3339)
+		resp.ConfirmedAt = &confirmedAt
+	}
+
+	for _, item := range booking.Items {
+		itemResp := BookingItemResponse{
+			SeatID: item.SeatID.String(),
+			Price:  item.PriceAtBooking,
+		}
+
+		if item.Seat != nil {
+			itemResp.Section = item.Seat.Section
+			itemResp.RowNumber = item.Seat.RowNumber
+			itemResp.SeatNumber = item.Seat.SeatNumber
+		}
+
+		resp.Items = append(resp.Items, itemResp)
+	}
+
+	return resp
+}
+
+func encodeBookingCursor(cursor domain.BookingCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeBookingCursor(encoded string) (*domain.BookingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), ",", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &domain.BookingCursor{CreatedAt: createdAt, ID: id}, nil
+}
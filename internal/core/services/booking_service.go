@@ -2,16 +2,19 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/redis/go-redis/v9"
 	"github.com/srgjo27/scalable_ticket/internal/core/domain"
 	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 type CreateBookingRequest struct {
@@ -27,74 +30,182 @@ type CreateBookingResponse struct {
 	ExpiresAt   string  `json:"expires_at"`
 }
 
+// ErrSeatUnavailable matches, with errors.Is, every error reporting a seat
+// that is taken or was taken by a concurrent request, whichever reservation
+// path found it.
+var ErrSeatUnavailable = errors.New("seat is not available")
+
+type seatUnavailableError struct {
+	seat string
+}
+
+func seatUnavailable(seat string) error {
+	return &seatUnavailableError{seat: seat}
+}
+
+func (e *seatUnavailableError) Error() string {
+	return fmt.Sprintf("seat %s is not available", e.seat)
+}
+
+func (e *seatUnavailableError) Is(target error) bool {
+	return target == ErrSeatUnavailable
+}
+
+const (
+	defaultHoldDuration      = 10 * time.Minute
+	defaultCleanupInterval   = 1 * time.Minute
+	defaultReconcileInterval = 30 * time.Second
+	defaultSeatPrice         = 100000.00
+)
+
+// BookingConfig overrides the service's timings. Zero fields keep the
+// defaults.
+type BookingConfig struct {
+	HoldDuration      time.Duration
+	CleanupInterval   time.Duration
+	ReconcileInterval time.Duration
+}
+
 type BookingService struct {
 	seatRepo    ports.SeatRepository
 	bookingRepo ports.BookingRepository
-	redisClient *redis.Client
+	seatCache   ports.SeatCache
+	cache       ports.Cache
+	seatLoads   singleflight.Group
+	metrics     ports.Metrics
+
+	holdDuration      time.Duration
+	cleanupInterval   time.Duration
+	reconcileInterval time.Duration
+
+	// Unix nanoseconds of the cleanup worker's last tick, see
+	// CheckCleanupWorker.
+	cleanupHeartbeat atomic.Int64
+
+	// Optional Redis-first reservation mode, see EnableReservationMode.
+	eventRepo        ports.EventRepository
+	reservations     ports.SeatReservationStore
+	reservationQueue chan queuedReservation
+	reservationModes sync.Map
+
+	// Reservations queued or being written, by event and booking, so the
+	// reconciler doesn't mistake a slow write for a lost one.
+	pendingWritesMu sync.Mutex
+	pendingWrites   map[uuid.UUID]map[uuid.UUID]struct{}
+
+	// One seat poller per watched event, see WatchSeats.
+	seatWatchMu sync.Mutex
+	seatWatches map[uuid.UUID]*seatPoller
 }
 
-func NewBookingService(seatRepo ports.SeatRepository, bookingRepo ports.BookingRepository, redisClient *redis.Client) *BookingService {
+func NewBookingService(seatRepo ports.SeatRepository, bookingRepo ports.BookingRepository, seatCache ports.SeatCache, cache ports.Cache) *BookingService {
 	return &BookingService{
 		seatRepo:    seatRepo,
 		bookingRepo: bookingRepo,
-		redisClient: redisClient,
+		seatCache:   seatCache,
+		cache:       cache,
+		metrics:     noopMetrics{},
+		seatWatches: make(map[uuid.UUID]*seatPoller),
+
+		holdDuration:      defaultHoldDuration,
+		cleanupInterval:   defaultCleanupInterval,
+		reconcileInterval: defaultReconcileInterval,
+	}
+}
+
+// Configure applies cfg. Call it before starting any background workers.
+func (s *BookingService) Configure(cfg BookingConfig) {
+	if cfg.HoldDuration > 0 {
+		s.holdDuration = cfg.HoldDuration
+	}
+
+	if cfg.CleanupInterval > 0 {
+		s.cleanupInterval = cfg.CleanupInterval
+	}
+
+	if cfg.ReconcileInterval > 0 {
+		s.reconcileInterval = cfg.ReconcileInterval
 	}
 }
 
 func (s *BookingService) CreateBooking(ctx context.Context, req CreateBookingRequest) (*CreateBookingResponse, error) {
-	userID, err := uuid.Parse(req.UserID)
+	ctx, span := tracer.Start(ctx, "BookingService.CreateBooking",
+		trace.WithAttributes(eventIDAttr(req.EventID), seatCountAttr(len(req.SeatIDs))))
+
+	start := time.Now()
+	resp, err := s.createBooking(ctx, req)
+
+	outcome := "created"
 	if err != nil {
-		return nil, errors.New("invalid user id")
+		outcome = "failed"
+	} else {
+		span.SetAttributes(bookingIDAttr(resp.BookingID))
 	}
 
-	eventID, err := uuid.Parse(req.EventID)
+	s.metrics.BookingDuration(outcome, time.Since(start))
+	endSpan(span, err)
+
+	return resp, err
+}
+
+func (s *BookingService) createBooking(ctx context.Context, req CreateBookingRequest) (*CreateBookingResponse, error) {
+	valid, err := req.validate()
 	if err != nil {
-		return nil, errors.New("invalid event id")
+		return nil, s.bookingFailed(ctx, valid.eventID, failInvalidRequest, err)
 	}
 
-	if len(req.SeatIDs) == 0 {
-		return nil, errors.New("no seats selected")
+	eventID, userID := valid.eventID, valid.userID
+	ctx = logging.With(ctx, "event_id", eventID, "user_id", userID)
+
+	mode := s.reservationMode(ctx, eventID)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("event.reservation_mode", string(mode)))
+
+	if mode == domain.ReservationRedis {
+		return s.reserveInRedis(ctx, userID, eventID, valid.seatIDs)
 	}
 
 	bookingID := uuid.New()
+	ctx = logging.With(ctx, "booking_id", bookingID)
 
 	var lockedSeatIDs []uuid.UUID
 	var totalAmount float64
 	var bookingItems []domain.BookingItem
 
-	for _, seatIDStr := range req.SeatIDs {
-		seatID, _ := uuid.Parse(seatIDStr)
-
+	for _, seatID := range valid.seatIDs {
 		seat, err := s.seatRepo.GetByID(ctx, seatID)
 		if err != nil {
-			s.rollbackLocks(ctx, lockedSeatIDs)
-			return nil, fmt.Errorf("seat not found: %s", seatIDStr)
+			s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+			return nil, s.bookingFailed(ctx, eventID, failSeatNotFound, fmt.Errorf("seat not found: %s", seatID))
 		}
 
 		if seat == nil {
-			s.rollbackLocks(ctx, lockedSeatIDs)
-			return nil, fmt.Errorf("internal error: seat data is nil for id %s", seatIDStr)
+			s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+			return nil, s.bookingFailed(ctx, eventID, failSeatNotFound, fmt.Errorf("internal error: seat data is nil for id %s", seatID))
 		}
 
-		if !seat.IsAvailable() {
-			s.rollbackLocks(ctx, lockedSeatIDs)
-			return nil, fmt.Errorf("seat %s is not available", seat.SeatNumber)
+		if seat.EventID != eventID {
+			s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+			return nil, s.bookingFailed(ctx, eventID, failWrongEvent, &ValidationError{Fields: []FieldError{{
+				Field:   "seat_ids",
+				Message: fmt.Sprintf("contains seat %s of another event", seatID),
+			}}})
 		}
 
-		if seat.EventID != eventID {
-			s.rollbackLocks(ctx, lockedSeatIDs)
-			return nil, errors.New("seat does not belong to this event")
+		if !seat.IsAvailable() {
+			s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+			return nil, s.bookingFailed(ctx, eventID, failSeatUnavailable, seatUnavailable(seat.SeatNumber))
 		}
 
-		err = s.seatRepo.LockSeat(ctx, seat.ID, bookingID, seat.Version)
+		err = s.seatRepo.LockSeat(ctx, seat.ID, bookingID, userID, seat.Version)
 		if err != nil {
-			s.rollbackLocks(ctx, lockedSeatIDs)
-			return nil, fmt.Errorf("failed to lock seat %s: maybe taken by another user", seat.SeatNumber)
+			s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+			s.metrics.LockConflict(eventID)
+			return nil, s.bookingFailed(ctx, eventID, failLockConflict, seatUnavailable(seat.SeatNumber))
 		}
 
 		lockedSeatIDs = append(lockedSeatIDs, seat.ID)
 
-		seatPrice := 100000.00
+		seatPrice := defaultSeatPrice
 		totalAmount += seatPrice
 
 		bookingItems = append(bookingItems, domain.BookingItem{
@@ -105,7 +216,7 @@ func (s *BookingService) CreateBooking(ctx context.Context, req CreateBookingReq
 		})
 	}
 
-	expiresAt := time.Now().Add(10 * time.Minute)
+	expiresAt := time.Now().Add(s.holdDuration)
 
 	newBooking := &domain.Booking{
 		ID:          bookingID,
@@ -120,12 +231,17 @@ func (s *BookingService) CreateBooking(ctx context.Context, req CreateBookingReq
 
 	err = s.bookingRepo.CreateBooking(ctx, newBooking)
 	if err != nil {
-		s.rollbackLocks(ctx, lockedSeatIDs)
-		return nil, errors.New("internal server error: failed to create booking")
+		s.rollbackLocks(ctx, lockedSeatIDs, bookingID, userID)
+		return nil, s.bookingFailed(ctx, eventID, failPersistError, errors.New("internal server error: failed to create booking"))
 	}
 
-	cacheKey := fmt.Sprintf("seats:%s", req.EventID)
-	s.redisClient.Del(ctx, cacheKey)
+	s.metrics.BookingCreated(eventID)
+	logging.FromContext(ctx).InfoContext(ctx, "Booking created", "seat_count", len(lockedSeatIDs))
+
+	if err := s.seatCache.RemoveSeats(ctx, eventID, lockedSeatIDs); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to remove booked seats from cache", "error", err)
+	}
+	s.invalidateSeatMap(ctx, eventID)
 
 	return &CreateBookingResponse{
 		BookingID:   bookingID.String(),
@@ -135,74 +251,112 @@ func (s *BookingService) CreateBooking(ctx context.Context, req CreateBookingReq
 	}, nil
 }
 
-func (s *BookingService) rollbackLocks(ctx context.Context, seatIDs []uuid.UUID) {
+func (s *BookingService) rollbackLocks(ctx context.Context, seatIDs []uuid.UUID, bookingID uuid.UUID, actorID uuid.UUID) {
 	for _, id := range seatIDs {
-		_ = s.seatRepo.UnlockSeat(ctx, id)
+		_ = s.seatRepo.UnlockSeat(ctx, id, bookingID, actorID)
 	}
 }
 
 func (s *BookingService) RunBackgroundCleanup(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(s.cleanupInterval)
 	defer ticker.Stop()
 
-	log.Println("Background Worker started: Checking expired bookings every 1 minute...")
+	ctx = logging.With(ctx, "worker", "booking_cleanup")
+	logger := logging.FromContext(ctx)
+	logger.InfoContext(ctx, "Background worker started", "interval", s.cleanupInterval.String())
+
+	s.cleanupHeartbeat.Store(time.Now().UnixNano())
 
 	for {
 		select {
 		case <-ctx.Done():
-			log.Println("Background Worker stopped.")
+			logger.InfoContext(ctx, "Background worker stopped")
 			return
 		case <-ticker.C:
 			s.processExpiredBookings(ctx)
+			s.cleanupHeartbeat.Store(time.Now().UnixNano())
 		}
 	}
 }
 
+// CheckCleanupWorker returns an error unless RunBackgroundCleanup has
+// finished a run within the last three cleanup intervals. A run that hangs on
+// the database stops the heartbeat just like a worker that has exited.
+func (s *BookingService) CheckCleanupWorker(ctx context.Context) error {
+	last := s.cleanupHeartbeat.Load()
+	if last == 0 {
+		return errors.New("cleanup worker not running")
+	}
+
+	if since := time.Since(time.Unix(0, last)); since > 3*s.cleanupInterval {
+		return fmt.Errorf("cleanup worker last ticked %s ago", since.Round(time.Second))
+	}
+
+	return nil
+}
+
 func (s *BookingService) processExpiredBookings(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "BookingService.processExpiredBookings")
+	defer span.End()
+
 	ids, err := s.bookingRepo.GetExpiredBookings(ctx)
+	span.SetAttributes(attribute.Int("booking.count", len(ids)))
 	if err != nil {
-		log.Printf("Error fetching expired bookings: %v", err)
+		span.RecordError(err)
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to fetch expired bookings", "error", err)
 		return
 	}
 
+	// The batch is capped, so the backlog is counted separately.
+	if backlog, err := s.bookingRepo.CountExpiredBookings(ctx); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to count expired bookings", "error", err)
+	} else {
+		s.metrics.CleanupBacklog(backlog)
+	}
+
 	if len(ids) == 0 {
 		return
 	}
 
-	log.Printf("Found %d expired bookings. Cleaning up...", len(ids))
+	logging.FromContext(ctx).InfoContext(ctx, "Cleaning up expired bookings", "count", len(ids))
 
 	for _, id := range ids {
-		if err := s.bookingRepo.CancelBooking(ctx, id); err != nil {
-			log.Printf("Failed to cancel booking %s: %v", id, err)
-		} else {
-			log.Printf("Booking %s expired and seats released.", id)
+		// Stop between bookings on shutdown; the rest are picked up by the
+		// next run on this or another pod. A booking already started is
+		// finished, so its transaction never races the pool being closed.
+		if ctx.Err() != nil {
+			logging.FromContext(ctx).InfoContext(ctx, "Cleanup interrupted by shutdown")
+			return
 		}
-	}
-}
-
-func (s *BookingService) GetAvailableSeats(ctx context.Context, eventIDStr string) ([]domain.Seat, error) {
-	eventID, err := uuid.Parse(eventIDStr)
-	if err != nil {
-		return nil, errors.New("invalid event id")
-	}
 
-	cacheKey := fmt.Sprintf("seats:%s", eventIDStr)
+		bookingCtx := logging.With(context.WithoutCancel(ctx), "booking_id", id)
+		logger := logging.FromContext(bookingCtx)
 
-	cachedData, err := s.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var seats []domain.Seat
-		if err := json.Unmarshal([]byte(cachedData), &seats); err == nil {
-			return seats, nil
+		booking, err := s.bookingRepo.GetByID(bookingCtx, id)
+		if err != nil {
+			logger.WarnContext(bookingCtx, "Failed to load expired booking, seat cache will not be updated", "error", err)
+		} else {
+			bookingCtx = logging.With(bookingCtx, "user_id", booking.UserID, "event_id", booking.EventID)
+			logger = logging.FromContext(bookingCtx)
 		}
-	}
 
-	seats, err := s.seatRepo.GetAvailableSeatsByEvent(ctx, eventID)
-	if err != nil {
-		return nil, err
-	}
+		if err := s.bookingRepo.CancelBooking(bookingCtx, id); err != nil {
+			if errors.Is(err, ports.ErrBookingNotPending) {
+				// Confirmed or cancelled since it was listed; its seats
+				// are no longer ours to release.
+				logger.DebugContext(bookingCtx, "Expired booking is no longer pending, skipped")
+				continue
+			}
 
-	dataToCache, _ := json.Marshal(seats)
-	s.redisClient.Set(ctx, cacheKey, dataToCache, 1*time.Minute)
+			logger.ErrorContext(bookingCtx, "Failed to cancel expired booking", "error", err)
+			continue
+		}
 
-	return seats, nil
+		logger.InfoContext(bookingCtx, "Booking expired and seats released")
+		s.metrics.BookingExpired(expireHoldTimeout)
+
+		if booking != nil {
+			s.refreshReleasedSeats(bookingCtx, booking)
+		}
+	}
 }
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
-	"github.com/go-redis/redismock/v9"
 	"github.com/google/uuid"
 	"github.com/srgjo27/scalable_ticket/internal/core/domain"
 	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
@@ -19,9 +19,10 @@ func TestCreateBooking_Success(t *testing.T) {
 	mockSeatRepo := mocks.NewSeatRepository(t)
 	mockBookingRepo := mocks.NewBookingRepository(t)
 
-	db, mockRedis := redismock.NewClientMock()
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
 
-	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, db)
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -42,12 +43,12 @@ func TestCreateBooking_Success(t *testing.T) {
 		SeatIDs: []string{seatID.String()},
 	}
 
-	mockSeatRepo.On("GetByID", ctx, seatID).Return(mockSeat, nil)
-	mockSeatRepo.On("LockSeat", ctx, seatID, mock.AnythingOfType("uuid.UUID"), 1).Return(nil)
-	mockBookingRepo.On("CreateBooking", ctx, mock.AnythingOfType("*domain.Booking")).Return(nil)
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(mockSeat, nil)
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.AnythingOfType("uuid.UUID"), userID, 1).Return(nil)
+	mockBookingRepo.On("CreateBooking", mock.Anything, mock.AnythingOfType("*domain.Booking")).Return(nil)
 
-	cacheKey := fmt.Sprintf("seats:%s", eventID.String())
-	mockRedis.ExpectDel(cacheKey).SetVal(1)
+	mockSeatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{seatID}).Return(nil)
+	mockCache.On("Delete", mock.Anything, fmt.Sprintf("seatmap:%s", eventID), fmt.Sprintf("seatmap:%s:ids", eventID)).Return(nil)
 
 	resp, err := service.CreateBooking(ctx, req)
 
@@ -55,18 +56,15 @@ func TestCreateBooking_Success(t *testing.T) {
 	if assert.NotNil(t, resp) {
 		assert.Equal(t, 100000.0, resp.TotalAmount)
 	}
-
-	if err := mockRedis.ExpectationsWereMet(); err != nil {
-		t.Errorf("there were unfulfilled expectations: %s", err)
-	}
 }
 
 func TestCreateBooking_Fail_SeatLocked(t *testing.T) {
 	mockSeatRepo := mocks.NewSeatRepository(t)
 	mockBookingRepo := mocks.NewBookingRepository(t)
-	db, _ := redismock.NewClientMock()
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
 
-	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, db)
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
 
 	ctx := context.Background()
 	seatID := uuid.New()
@@ -85,12 +83,286 @@ func TestCreateBooking_Fail_SeatLocked(t *testing.T) {
 		SeatIDs: []string{seatID.String()},
 	}
 
-	mockSeatRepo.On("GetByID", ctx, seatID).Return(mockSeat, nil)
-	mockSeatRepo.On("LockSeat", ctx, seatID, mock.Anything, 1).Return(errors.New("optimistic lock failed"))
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(mockSeat, nil)
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.Anything, mock.Anything, 1).Return(errors.New("optimistic lock failed"))
 
 	resp, err := service.CreateBooking(ctx, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, resp)
-	assert.Contains(t, err.Error(), "failed to lock seat")
+	assert.ErrorIs(t, err, services.ErrSeatUnavailable)
+}
+
+func TestCreateBooking_LockConflictIsRecorded(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+	mockMetrics := mocks.NewMetrics(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+	service.SetMetrics(mockMetrics)
+
+	ctx := context.Background()
+	seatID := uuid.New()
+	eventID := uuid.New()
+
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(&domain.Seat{ID: seatID, EventID: eventID, Status: domain.SeatAvailable, Version: 1}, nil)
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.Anything, mock.Anything, 1).Return(errors.New("optimistic lock failed"))
+
+	mockMetrics.On("LockConflict", eventID).Once()
+	mockMetrics.On("BookingFailed", eventID, "lock_conflict").Once()
+	mockMetrics.On("BookingDuration", "failed", mock.AnythingOfType("time.Duration")).Once()
+
+	_, err := service.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  uuid.New().String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{seatID.String()},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestGetBooking_OtherUserNotFound(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	bookingID := uuid.New()
+
+	mockBookingRepo.On("GetByID", ctx, bookingID).Return(&domain.Booking{
+		ID:     bookingID,
+		UserID: uuid.New(),
+		Status: domain.BookingPending,
+	}, nil)
+
+	resp, err := service.GetBooking(ctx, uuid.New().String(), bookingID.String())
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "booking not found")
+}
+
+func TestListUserBookings_Pagination(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	var bookings []domain.Booking
+	for i := 0; i < 3; i++ {
+		bookings = append(bookings, domain.Booking{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Status:    domain.BookingPending,
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+			ExpiresAt: now.Add(10 * time.Minute),
+		})
+	}
+
+	mockBookingRepo.On("ListByUser", ctx, mock.MatchedBy(func(f domain.BookingFilter) bool {
+		return f.UserID == userID && f.Limit == 3 && f.After == nil
+	})).Return(bookings, nil).Once()
+
+	resp, err := service.ListUserBookings(ctx, services.ListBookingsRequest{UserID: userID.String(), Limit: 2})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Len(t, resp.Bookings, 2)
+		assert.NotEmpty(t, resp.NextCursor)
+		assert.Greater(t, resp.Bookings[0].HoldRemainingSeconds, int64(0))
+		assert.Equal(t, domain.PaymentStatusUnpaid, resp.Bookings[0].PaymentStatus)
+	}
+
+	mockBookingRepo.On("ListByUser", ctx, mock.MatchedBy(func(f domain.BookingFilter) bool {
+		return f.After != nil && f.After.ID == bookings[1].ID && f.After.CreatedAt.Equal(bookings[1].CreatedAt)
+	})).Return(bookings[2:], nil).Once()
+
+	resp, err = service.ListUserBookings(ctx, services.ListBookingsRequest{UserID: userID.String(), Limit: 2, Cursor: resp.NextCursor})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Len(t, resp.Bookings, 1)
+		assert.Empty(t, resp.NextCursor)
+	}
+}
+
+func TestGetAvailableSeats_ServedFromCache(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	seat := domain.Seat{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "1", Status: domain.SeatAvailable, Version: 3}
+
+	mockSeatCache.On("AvailableSeats", ctx, eventID).Return([]domain.Seat{seat}, true)
+
+	seats, err := service.GetAvailableSeats(ctx, eventID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Seat{seat}, seats)
+	mockSeatRepo.AssertNotCalled(t, "GetAvailableSeatsByEvent", mock.Anything, mock.Anything)
+}
+
+func TestGetAvailableSeats_MissRebuildsCache(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	seats := []domain.Seat{
+		{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "2", Status: domain.SeatAvailable},
+		{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "1", Status: domain.SeatAvailable},
+	}
+
+	released := false
+	mockSeatCache.On("AvailableSeats", ctx, eventID).Return(nil, false)
+	mockSeatCache.On("LockRebuild", mock.Anything, eventID).Return(true, func() { released = true })
+	mockSeatCache.On("SeatGeneration", mock.Anything, eventID).Return(int64(7), nil)
+	mockSeatRepo.On("GetAvailableSeatsByEvent", mock.Anything, eventID).Return(seats, nil)
+	mockSeatCache.On("ReplaceAvailableSeats", mock.Anything, eventID, mock.Anything, int64(7)).Return(true, nil)
+
+	result, err := service.GetAvailableSeats(ctx, eventID.String())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "1", result[0].SeatNumber)
+	}
+	assert.True(t, released)
+}
+
+func TestGetAvailableSeats_ReadsGenerationBeforeScan(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	seats := []domain.Seat{{ID: uuid.New(), EventID: eventID, SeatNumber: "1", Status: domain.SeatAvailable}}
+
+	// A booking removed a seat from the cache while Postgres was scanned, so
+	// the cache refuses the snapshot. The caller still gets the scan.
+	mockSeatCache.On("AvailableSeats", ctx, eventID).Return(nil, false)
+	mockSeatCache.On("LockRebuild", mock.Anything, eventID).Return(true, func() {})
+	mock.InOrder(
+		mockSeatCache.On("SeatGeneration", mock.Anything, eventID).Return(int64(3), nil),
+		mockSeatRepo.On("GetAvailableSeatsByEvent", mock.Anything, eventID).Return(seats, nil),
+		mockSeatCache.On("ReplaceAvailableSeats", mock.Anything, eventID, seats, int64(3)).Return(false, nil),
+	)
+
+	result, err := service.GetAvailableSeats(ctx, eventID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, seats, result)
+}
+
+func TestGetAvailableSeats_WaitsForOtherReplicaRebuild(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	seat := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}
+
+	mockSeatCache.On("AvailableSeats", mock.Anything, eventID).Return(nil, false).Twice()
+	mockSeatCache.On("LockRebuild", mock.Anything, eventID).Return(false, func() {})
+	mockSeatCache.On("AvailableSeats", mock.Anything, eventID).Return([]domain.Seat{seat}, true).Once()
+
+	seats, err := service.GetAvailableSeats(ctx, eventID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Seat{seat}, seats)
+	mockSeatRepo.AssertNotCalled(t, "GetAvailableSeatsByEvent", mock.Anything, mock.Anything)
+}
+
+func TestRunBackgroundCleanup_ReportsWholeBacklog(t *testing.T) {
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockMetrics := mocks.NewMetrics(t)
+
+	service := services.NewBookingService(mocks.NewSeatRepository(t), mockBookingRepo, mocks.NewSeatCache(t), mocks.NewCache(t))
+	service.Configure(services.BookingConfig{CleanupInterval: 10 * time.Millisecond})
+	service.SetMetrics(mockMetrics)
+
+	reported := make(chan struct{}, 1)
+
+	// The batch is empty here, but the count covers bookings beyond it.
+	mockBookingRepo.On("GetExpiredBookings", mock.Anything).Return(nil, nil)
+	mockBookingRepo.On("CountExpiredBookings", mock.Anything).Return(250, nil)
+	mockMetrics.On("CleanupBacklog", 250).Run(func(mock.Arguments) {
+		select {
+		case reported <- struct{}{}:
+		default:
+		}
+	})
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.RunBackgroundCleanup(workerCtx)
+	}()
+
+	select {
+	case <-reported:
+	case <-time.After(2 * time.Second):
+		t.Fatal("cleanup backlog was not reported")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestCheckCleanupWorker_TracksHeartbeat(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+	service.Configure(services.BookingConfig{CleanupInterval: 10 * time.Millisecond})
+
+	mockBookingRepo.On("GetExpiredBookings", mock.Anything).Return(nil, nil).Maybe()
+	mockBookingRepo.On("CountExpiredBookings", mock.Anything).Return(0, nil).Maybe()
+
+	ctx := context.Background()
+	assert.ErrorContains(t, service.CheckCleanupWorker(ctx), "not running")
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.RunBackgroundCleanup(workerCtx)
+	}()
+
+	assert.Eventually(t, func() bool { return service.CheckCleanupWorker(ctx) == nil }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Eventually(t, func() bool { return service.CheckCleanupWorker(ctx) != nil }, time.Second, 5*time.Millisecond)
 }
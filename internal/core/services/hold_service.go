@@ -0,0 +1,533 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// MaxSeatsPerHold caps the distinct seats one hold may take off sale.
+	MaxSeatsPerHold = 500
+
+	maxHoldNameLength = 255
+)
+
+type CreateHoldRequest struct {
+	ActorID   string
+	EventID   string
+	Name      string
+	Reason    string
+	Owner     string
+	SeatIDs   []string
+	ReleaseAt string
+}
+
+type ConvertHoldRequest struct {
+	ActorID string
+	HoldID  string
+	UserID  string
+}
+
+type HoldResponse struct {
+	HoldID    string   `json:"hold_id"`
+	EventID   string   `json:"event_id"`
+	Name      string   `json:"name"`
+	Reason    string   `json:"reason"`
+	Owner     string   `json:"owner"`
+	Status    string   `json:"status"`
+	SeatIDs   []string `json:"seat_ids"`
+	ReleaseAt *string  `json:"release_at"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	ClosedBy  *string  `json:"closed_by"`
+	ClosedAt  *string  `json:"closed_at"`
+	BookingID *string  `json:"booking_id"`
+}
+
+// HoldService lets organizers take seats off sale. Held seats are
+// UNAVAILABLE and missing from the seat cache, so neither bookings nor
+// best-available selection can pick them up.
+type HoldService struct {
+	holdRepo  ports.HoldRepository
+	seatRepo  ports.SeatRepository
+	seatCache ports.SeatCache
+	cache     ports.Cache
+
+	reservations ports.SeatReservationStore
+}
+
+func NewHoldService(holdRepo ports.HoldRepository, seatRepo ports.SeatRepository, seatCache ports.SeatCache, cache ports.Cache) *HoldService {
+	return &HoldService{
+		holdRepo:  holdRepo,
+		seatRepo:  seatRepo,
+		seatCache: seatCache,
+		cache:     cache,
+	}
+}
+
+// SetReservationStore makes CreateHold refuse seats with a live Redis claim,
+// whose bookings may not have reached Postgres yet. Call it whenever
+// reservation mode is enabled on the booking service.
+func (s *HoldService) SetReservationStore(store ports.SeatReservationStore) {
+	s.reservations = store
+}
+
+// validHold is a CreateHoldRequest that passed validation.
+type validHold struct {
+	actorID   uuid.UUID
+	eventID   uuid.UUID
+	name      string
+	reason    string
+	owner     string
+	seatIDs   []uuid.UUID
+	releaseAt *time.Time
+}
+
+func (r CreateHoldRequest) validate(now time.Time) (validHold, error) {
+	var v validator
+
+	hold := validHold{
+		actorID: v.uuid("user_id", r.ActorID),
+		eventID: v.uuid("event_id", r.EventID),
+		name:    v.text("name", r.Name, maxHoldNameLength),
+		reason:  v.text("reason", r.Reason, maxHoldNameLength),
+		owner:   v.text("owner", r.Owner, maxHoldNameLength),
+		seatIDs: v.seatIDs("seat_ids", r.SeatIDs, MaxSeatsPerHold),
+	}
+
+	if r.ReleaseAt != "" {
+		releaseAt, err := time.Parse(time.RFC3339, r.ReleaseAt)
+		if err != nil {
+			v.add("release_at", "must be an RFC 3339 time")
+		} else if !releaseAt.After(now) {
+			v.add("release_at", "must be in the future")
+		} else {
+			hold.releaseAt = &releaseAt
+		}
+	}
+
+	return hold, v.err()
+}
+
+// CreateHold takes the requested seats off sale. Every seat must belong to
+// the event and be AVAILABLE, otherwise nothing is held.
+func (s *HoldService) CreateHold(ctx context.Context, req CreateHoldRequest) (resp *HoldResponse, err error) {
+	ctx, span := tracer.Start(ctx, "HoldService.CreateHold", trace.WithAttributes(eventIDAttr(req.EventID)))
+	defer func() { endSpan(span, err) }()
+
+	valid, err := req.validate(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("hold.seat_count", len(valid.seatIDs)))
+
+	seats, err := s.seatRepo.GetSeatsByEvent(ctx, valid.eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]domain.Seat, len(seats))
+	for _, seat := range seats {
+		byID[seat.ID] = seat
+	}
+
+	var v validator
+	for _, seatID := range valid.seatIDs {
+		if _, ok := byID[seatID]; ok {
+			continue
+		}
+
+		// Not a seat of this event: tell a seat of another event apart from
+		// one that doesn't exist.
+		if _, err := s.seatRepo.GetByID(ctx, seatID); errors.Is(err, ports.ErrSeatNotFound) {
+			v.add("seat_ids", "seat %s not found", seatID)
+		} else if err != nil {
+			return nil, err
+		} else {
+			v.add("seat_ids", "contains seat %s of another event", seatID)
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	for _, seatID := range valid.seatIDs {
+		if seat := byID[seatID]; !seat.IsAvailable() {
+			return nil, seatUnavailable(seatID.String())
+		}
+	}
+
+	// Take the seats out of the cache before the database. In Redis
+	// reservation mode that cache is what claims are made against, so no new
+	// claim can slip in once the live ones have been checked.
+	if err := s.seatCache.RemoveSeats(ctx, valid.eventID, valid.seatIDs); err != nil {
+		ctx := logging.With(ctx, "event_id", valid.eventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to remove held seats from cache", "error", err)
+	}
+
+	claimed, err := s.claimedSeats(ctx, valid.eventID, valid.seatIDs)
+	if err != nil {
+		s.restoreSeats(ctx, valid.eventID, valid.seatIDs, nil)
+		return nil, err
+	}
+
+	if len(claimed) > 0 {
+		s.restoreSeats(ctx, valid.eventID, valid.seatIDs, claimed)
+		return nil, seatUnavailable(claimed[0].String())
+	}
+
+	hold := &domain.InventoryHold{
+		ID:        uuid.New(),
+		EventID:   valid.eventID,
+		Name:      valid.name,
+		Reason:    valid.reason,
+		Owner:     valid.owner,
+		SeatIDs:   valid.seatIDs,
+		Status:    domain.HoldActive,
+		ReleaseAt: valid.releaseAt,
+		CreatedBy: valid.actorID,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.holdRepo.CreateHold(ctx, hold); err != nil {
+		s.restoreSeats(ctx, valid.eventID, valid.seatIDs, nil)
+		return nil, err
+	}
+
+	invalidateSeatMap(ctx, s.cache, valid.eventID)
+
+	ctx = logging.With(ctx, "hold_id", hold.ID, "event_id", hold.EventID)
+	logging.FromContext(ctx).InfoContext(ctx, "Seats held", "seat_count", len(hold.SeatIDs), "owner", hold.Owner)
+
+	return toHoldResponse(hold), nil
+}
+
+func (s *HoldService) GetHold(ctx context.Context, holdIDStr string) (*HoldResponse, error) {
+	holdID, err := uuid.Parse(holdIDStr)
+	if err != nil {
+		return nil, errors.New("invalid hold id")
+	}
+
+	hold, err := s.holdRepo.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHoldResponse(hold), nil
+}
+
+// ListHolds returns an event's holds, newest first. statusStr optionally
+// filters by ACTIVE, RELEASED or CONVERTED.
+func (s *HoldService) ListHolds(ctx context.Context, eventIDStr string, statusStr string) ([]HoldResponse, error) {
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	var status *domain.HoldStatus
+	if statusStr != "" {
+		st := domain.HoldStatus(strings.ToUpper(statusStr))
+		switch st {
+		case domain.HoldActive, domain.HoldReleased, domain.HoldConverted:
+			status = &st
+		default:
+			return nil, errors.New("invalid hold status")
+		}
+	}
+
+	holds, err := s.holdRepo.ListHoldsByEvent(ctx, eventID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]HoldResponse, 0, len(holds))
+	for i := range holds {
+		resp = append(resp, *toHoldResponse(&holds[i]))
+	}
+
+	return resp, nil
+}
+
+// ReleaseHold puts an active hold's seats back on sale.
+func (s *HoldService) ReleaseHold(ctx context.Context, actorIDStr string, holdIDStr string) (resp *HoldResponse, err error) {
+	ctx, span := tracer.Start(ctx, "HoldService.ReleaseHold", trace.WithAttributes(holdIDAttr(holdIDStr)))
+	defer func() { endSpan(span, err) }()
+
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+
+	holdID, err := uuid.Parse(holdIDStr)
+	if err != nil {
+		return nil, errors.New("invalid hold id")
+	}
+
+	hold, err := s.release(ctx, holdID, &actorID, domain.AuditReasonHoldReleased)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHoldResponse(hold), nil
+}
+
+// ConvertHold gives an active hold's seats to req.UserID as a confirmed,
+// zero-priced booking paid with a complimentary payment.
+func (s *HoldService) ConvertHold(ctx context.Context, req ConvertHoldRequest) (resp *HoldResponse, err error) {
+	ctx, span := tracer.Start(ctx, "HoldService.ConvertHold", trace.WithAttributes(holdIDAttr(req.HoldID)))
+	defer func() { endSpan(span, err) }()
+
+	actorID, err := uuid.Parse(req.ActorID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+
+	holdID, err := uuid.Parse(req.HoldID)
+	if err != nil {
+		return nil, errors.New("invalid hold id")
+	}
+
+	var v validator
+	userID := v.uuid("user_id", req.UserID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	hold, err := s.holdRepo.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status != domain.HoldActive {
+		return nil, ports.ErrHoldNotActive
+	}
+
+	booking := complimentaryBooking(hold, userID, time.Now())
+	span.SetAttributes(bookingIDAttr(booking.ID.String()))
+
+	if err := s.holdRepo.ConvertHold(ctx, holdID, booking, actorID); err != nil {
+		return nil, err
+	}
+
+	invalidateSeatMap(ctx, s.cache, hold.EventID)
+
+	ctx = logging.With(ctx, "hold_id", holdID, "booking_id", booking.ID)
+	logging.FromContext(ctx).InfoContext(ctx, "Hold converted to complimentary booking", "seat_count", len(hold.SeatIDs))
+
+	hold, err = s.holdRepo.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHoldResponse(hold), nil
+}
+
+// RunAutoRelease releases holds whose release time has passed, every
+// interval, until ctx is cancelled.
+func (s *HoldService) RunAutoRelease(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx = logging.With(ctx, "worker", "hold_release")
+	logger := logging.FromContext(ctx)
+	logger.InfoContext(ctx, "Background worker started", "interval", interval.String())
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Background worker stopped")
+			return
+		case <-ticker.C:
+			s.ReleaseDueHolds(ctx)
+		}
+	}
+}
+
+// ReleaseDueHolds releases one batch of holds whose release time has passed.
+// Several replicas may run it at once; a hold released by another replica is
+// skipped.
+func (s *HoldService) ReleaseDueHolds(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "HoldService.ReleaseDueHolds")
+	defer span.End()
+
+	ids, err := s.holdRepo.GetDueHolds(ctx, time.Now())
+	span.SetAttributes(attribute.Int("hold.count", len(ids)))
+	if err != nil {
+		span.RecordError(err)
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to fetch due holds", "error", err)
+		return
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+
+		holdCtx := logging.With(context.WithoutCancel(ctx), "hold_id", id)
+		logger := logging.FromContext(holdCtx)
+
+		if _, err := s.release(holdCtx, id, nil, domain.AuditReasonHoldAutoReleased); err != nil {
+			if errors.Is(err, ports.ErrHoldNotActive) {
+				logger.DebugContext(holdCtx, "Hold already closed")
+				continue
+			}
+
+			logger.ErrorContext(holdCtx, "Failed to auto-release hold", "error", err)
+			continue
+		}
+
+		logger.InfoContext(holdCtx, "Hold auto-released")
+	}
+}
+
+// release closes a hold and puts its seats back in the seat cache. actorID
+// is nil for automatic releases.
+func (s *HoldService) release(ctx context.Context, holdID uuid.UUID, actorID *uuid.UUID, reason string) (*domain.InventoryHold, error) {
+	if err := s.holdRepo.ReleaseHold(ctx, holdID, actorID, reason); err != nil {
+		return nil, err
+	}
+
+	hold, err := s.holdRepo.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.restoreSeats(ctx, hold.EventID, hold.SeatIDs, nil)
+	invalidateSeatMap(ctx, s.cache, hold.EventID)
+
+	return hold, nil
+}
+
+// claimedSeats returns the seats among seatIDs with a live claim in the
+// reservation store.
+func (s *HoldService) claimedSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if s.reservations == nil {
+		return nil, nil
+	}
+
+	holds, err := s.reservations.Holds(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check seat reservations: %w", err)
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		wanted[id] = true
+	}
+
+	now := time.Now()
+	var claimed []uuid.UUID
+	for _, h := range holds {
+		if wanted[h.SeatID] && !h.IsExpired(now) {
+			claimed = append(claimed, h.SeatID)
+		}
+	}
+
+	return claimed, nil
+}
+
+// restoreSeats puts the seats that are AVAILABLE in the database back into
+// the seat cache, except those in skip.
+func (s *HoldService) restoreSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID, skip []uuid.UUID) {
+	skipped := make(map[uuid.UUID]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+
+	var available []domain.Seat
+	for _, id := range seatIDs {
+		if skipped[id] {
+			continue
+		}
+
+		seat, err := s.seatRepo.GetByID(ctx, id)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to reload seat", "seat_id", id, "error", err)
+			continue
+		}
+
+		if seat.IsAvailable() {
+			available = append(available, *seat)
+		}
+	}
+
+	if err := s.seatCache.PutSeats(ctx, eventID, available); err != nil {
+		ctx := logging.With(ctx, "event_id", eventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to put seats back into cache", "error", err)
+	}
+}
+
+func complimentaryBooking(hold *domain.InventoryHold, userID uuid.UUID, now time.Time) *domain.Booking {
+	booking := &domain.Booking{
+		ID:          uuid.New(),
+		UserID:      userID,
+		EventID:     hold.EventID,
+		Status:      domain.BookingConfirmed,
+		CreatedAt:   now,
+		ExpiresAt:   now,
+		ConfirmedAt: &now,
+	}
+
+	for _, seatID := range hold.SeatIDs {
+		booking.Items = append(booking.Items, domain.BookingItem{
+			ID:        uuid.New(),
+			BookingID: booking.ID,
+			SeatID:    seatID,
+		})
+	}
+
+	booking.Payment = &domain.Payment{
+		ID:            uuid.New(),
+		BookingID:     booking.ID,
+		PaymentMethod: domain.PaymentMethodComplimentary,
+		Status:        domain.PaymentStatusSuccess,
+		PaidAt:        now,
+	}
+
+	return booking
+}
+
+func toHoldResponse(hold *domain.InventoryHold) *HoldResponse {
+	resp := &HoldResponse{
+		HoldID:    hold.ID.String(),
+		EventID:   hold.EventID.String(),
+		Name:      hold.Name,
+		Reason:    hold.Reason,
+		Owner:     hold.Owner,
+		Status:    string(hold.Status),
+		SeatIDs:   make([]string, 0, len(hold.SeatIDs)),
+		ReleaseAt: timeString(hold.ReleaseAt),
+		CreatedBy: hold.CreatedBy.String(),
+		CreatedAt: hold.CreatedAt.Format(time.RFC3339),
+		ClosedBy:  uuidString(hold.ClosedBy),
+		ClosedAt:  timeString(hold.ClosedAt),
+		BookingID: uuidString(hold.BookingID),
+	}
+
+	for _, id := range hold.SeatIDs {
+		resp.SeatIDs = append(resp.SeatIDs, id.String())
+	}
+
+	return resp
+}
+
+func timeString(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	str := t.Format(time.RFC3339)
+	return &str
+}
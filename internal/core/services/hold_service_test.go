@@ -0,0 +1,301 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type holdMocks struct {
+	holdRepo  *mocks.HoldRepository
+	seatRepo  *mocks.SeatRepository
+	seatCache *mocks.SeatCache
+	cache     *mocks.Cache
+}
+
+func newHoldService(t *testing.T) (*services.HoldService, holdMocks) {
+	m := holdMocks{
+		holdRepo:  mocks.NewHoldRepository(t),
+		seatRepo:  mocks.NewSeatRepository(t),
+		seatCache: mocks.NewSeatCache(t),
+		cache:     mocks.NewCache(t),
+	}
+
+	return services.NewHoldService(m.holdRepo, m.seatRepo, m.seatCache, m.cache), m
+}
+
+func TestCreateHold_TakesSeatsOffSale(t *testing.T) {
+	service, m := newHoldService(t)
+
+	actorID, eventID := uuid.New(), uuid.New()
+	seatA := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}
+	seatB := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}
+	releaseAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	m.seatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{seatA, seatB}, nil)
+	m.seatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{seatA.ID}).Return(nil).Once()
+	m.holdRepo.On("CreateHold", mock.Anything, mock.MatchedBy(func(h *domain.InventoryHold) bool {
+		return h.EventID == eventID && h.CreatedBy == actorID && h.Status == domain.HoldActive &&
+			h.Name == "Camera platform" && h.Owner == "production" &&
+			len(h.SeatIDs) == 1 && h.SeatIDs[0] == seatA.ID &&
+			h.ReleaseAt != nil && h.ReleaseAt.Equal(releaseAt)
+	})).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	resp, err := service.CreateHold(context.Background(), services.CreateHoldRequest{
+		ActorID:   actorID.String(),
+		EventID:   eventID.String(),
+		Name:      "  Camera platform ",
+		Reason:    "Broadcast",
+		Owner:     "production",
+		SeatIDs:   []string{seatA.ID.String(), seatA.ID.String()},
+		ReleaseAt: releaseAt.Format(time.RFC3339),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACTIVE", resp.Status)
+	assert.Equal(t, []string{seatA.ID.String()}, resp.SeatIDs)
+	assert.Equal(t, actorID.String(), resp.CreatedBy)
+	require.NotNil(t, resp.ReleaseAt)
+	assert.Equal(t, releaseAt.Format(time.RFC3339), *resp.ReleaseAt)
+	assert.Nil(t, resp.BookingID)
+}
+
+func TestCreateHold_ValidationListsEveryField(t *testing.T) {
+	service, _ := newHoldService(t)
+
+	_, err := service.CreateHold(context.Background(), services.CreateHoldRequest{
+		ActorID:   uuid.NewString(),
+		EventID:   uuid.NewString(),
+		Name:      " ",
+		Owner:     "production",
+		SeatIDs:   []string{"A1"},
+		ReleaseAt: time.Now().Add(-time.Minute).Format(time.RFC3339),
+	})
+
+	var invalid *services.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []services.FieldError{
+		{Field: "name", Message: "is required"},
+		{Field: "reason", Message: "is required"},
+		{Field: "seat_ids[0]", Message: "must be a UUID"},
+		{Field: "release_at", Message: "must be in the future"},
+	}, invalid.Fields)
+}
+
+func TestCreateHold_SeatOfAnotherEventIsInvalid(t *testing.T) {
+	service, m := newHoldService(t)
+
+	eventID, strayID, missingID := uuid.New(), uuid.New(), uuid.New()
+	m.seatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{}, nil)
+	m.seatRepo.On("GetByID", mock.Anything, strayID).Return(&domain.Seat{ID: strayID, EventID: uuid.New()}, nil)
+	m.seatRepo.On("GetByID", mock.Anything, missingID).Return(nil, ports.ErrSeatNotFound)
+
+	_, err := service.CreateHold(context.Background(), services.CreateHoldRequest{
+		ActorID: uuid.NewString(),
+		EventID: eventID.String(),
+		Name:    "Guests",
+		Reason:  "Artist guests",
+		Owner:   "artist",
+		SeatIDs: []string{strayID.String(), missingID.String()},
+	})
+
+	var invalid *services.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []services.FieldError{
+		{Field: "seat_ids", Message: "contains seat " + strayID.String() + " of another event"},
+		{Field: "seat_ids", Message: "seat " + missingID.String() + " not found"},
+	}, invalid.Fields)
+}
+
+func TestCreateHold_TakenSeatTouchesNothing(t *testing.T) {
+	service, m := newHoldService(t)
+
+	eventID := uuid.New()
+	seat := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatLocked}
+	m.seatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{seat}, nil)
+
+	_, err := service.CreateHold(context.Background(), services.CreateHoldRequest{
+		ActorID: uuid.NewString(),
+		EventID: eventID.String(),
+		Name:    "Sponsor",
+		Reason:  "Sponsor allocation",
+		Owner:   "sponsor",
+		SeatIDs: []string{seat.ID.String()},
+	})
+
+	assert.EqualError(t, err, "seat "+seat.ID.String()+" is not available")
+}
+
+func TestCreateHold_RefusesSeatsClaimedInReservationStore(t *testing.T) {
+	service, m := newHoldService(t)
+	store := mocks.NewSeatReservationStore(t)
+	service.SetReservationStore(store)
+
+	eventID := uuid.New()
+	claimed := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}
+	free := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}
+
+	m.seatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{claimed, free}, nil)
+	m.seatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{claimed.ID, free.ID}).Return(nil).Once()
+	store.On("Holds", mock.Anything, eventID).Return([]domain.SeatHold{
+		{SeatID: claimed.ID, BookingID: uuid.New(), ExpiresAt: time.Now().Add(time.Minute)},
+		{SeatID: free.ID, BookingID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)},
+	}, nil)
+
+	// Only the unclaimed seat goes back into the cache; the claimed one
+	// belongs to a booking that is on its way to Postgres.
+	m.seatRepo.On("GetByID", mock.Anything, free.ID).Return(&free, nil).Once()
+	m.seatCache.On("PutSeats", mock.Anything, eventID, []domain.Seat{free}).Return(nil).Once()
+
+	_, err := service.CreateHold(context.Background(), services.CreateHoldRequest{
+		ActorID: uuid.NewString(),
+		EventID: eventID.String(),
+		Name:    "Cameras",
+		Reason:  "Broadcast",
+		Owner:   "production",
+		SeatIDs: []string{claimed.ID.String(), free.ID.String()},
+	})
+
+	assert.EqualError(t, err, "seat "+claimed.ID.String()+" is not available")
+}
+
+func TestReleaseHold_PutsSeatsBackOnSale(t *testing.T) {
+	service, m := newHoldService(t)
+
+	actorID, holdID, eventID := uuid.New(), uuid.New(), uuid.New()
+	seat := domain.Seat{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable, Version: 3}
+	closedAt := time.Now()
+
+	m.holdRepo.On("ReleaseHold", mock.Anything, holdID, &actorID, domain.AuditReasonHoldReleased).Return(nil).Once()
+	m.holdRepo.On("GetHold", mock.Anything, holdID).Return(&domain.InventoryHold{
+		ID:       holdID,
+		EventID:  eventID,
+		SeatIDs:  []uuid.UUID{seat.ID},
+		Status:   domain.HoldReleased,
+		ClosedBy: &actorID,
+		ClosedAt: &closedAt,
+	}, nil)
+	m.seatRepo.On("GetByID", mock.Anything, seat.ID).Return(&seat, nil)
+	m.seatCache.On("PutSeats", mock.Anything, eventID, []domain.Seat{seat}).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	resp, err := service.ReleaseHold(context.Background(), actorID.String(), holdID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, "RELEASED", resp.Status)
+	assert.Equal(t, actorID.String(), *resp.ClosedBy)
+}
+
+func TestReleaseHold_InvalidIDs(t *testing.T) {
+	service, _ := newHoldService(t)
+
+	_, err := service.ReleaseHold(context.Background(), "admin", uuid.NewString())
+	assert.EqualError(t, err, "invalid user id")
+
+	_, err = service.ReleaseHold(context.Background(), uuid.NewString(), "H1")
+	assert.EqualError(t, err, "invalid hold id")
+}
+
+func TestConvertHold_BooksSeatsAsComplimentary(t *testing.T) {
+	service, m := newHoldService(t)
+
+	actorID, guestID, holdID, eventID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	seatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	hold := &domain.InventoryHold{ID: holdID, EventID: eventID, SeatIDs: seatIDs, Status: domain.HoldActive}
+
+	var bookingID uuid.UUID
+	m.holdRepo.On("GetHold", mock.Anything, holdID).Return(hold, nil).Once()
+	m.holdRepo.On("ConvertHold", mock.Anything, holdID, mock.MatchedBy(func(b *domain.Booking) bool {
+		bookingID = b.ID
+		return b.UserID == guestID && b.EventID == eventID &&
+			b.Status == domain.BookingConfirmed && b.TotalAmount == 0 && b.ConfirmedAt != nil &&
+			len(b.Items) == 2 && b.Items[0].PriceAtBooking == 0 &&
+			b.Payment != nil && b.Payment.PaymentMethod == domain.PaymentMethodComplimentary &&
+			b.Payment.Status == domain.PaymentStatusSuccess
+	}), actorID).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.holdRepo.On("GetHold", mock.Anything, holdID).Return(func(context.Context, uuid.UUID) (*domain.InventoryHold, error) {
+		converted := *hold
+		converted.Status = domain.HoldConverted
+		converted.BookingID = &bookingID
+		return &converted, nil
+	}).Once()
+
+	resp, err := service.ConvertHold(context.Background(), services.ConvertHoldRequest{
+		ActorID: actorID.String(),
+		HoldID:  holdID.String(),
+		UserID:  guestID.String(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "CONVERTED", resp.Status)
+	require.NotNil(t, resp.BookingID)
+	assert.Equal(t, bookingID.String(), *resp.BookingID)
+}
+
+func TestConvertHold_RequiresActiveHoldAndRecipient(t *testing.T) {
+	service, m := newHoldService(t)
+	holdID := uuid.New()
+
+	_, err := service.ConvertHold(context.Background(), services.ConvertHoldRequest{
+		ActorID: uuid.NewString(),
+		HoldID:  holdID.String(),
+	})
+
+	var invalid *services.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []services.FieldError{{Field: "user_id", Message: "is required"}}, invalid.Fields)
+
+	m.holdRepo.On("GetHold", mock.Anything, holdID).Return(&domain.InventoryHold{ID: holdID, Status: domain.HoldReleased}, nil)
+
+	_, err = service.ConvertHold(context.Background(), services.ConvertHoldRequest{
+		ActorID: uuid.NewString(),
+		HoldID:  holdID.String(),
+		UserID:  uuid.NewString(),
+	})
+	assert.EqualError(t, err, "hold is not active")
+}
+
+func TestListHolds_RejectsUnknownStatus(t *testing.T) {
+	service, m := newHoldService(t)
+	eventID := uuid.New()
+
+	_, err := service.ListHolds(context.Background(), eventID.String(), "pending")
+	assert.EqualError(t, err, "invalid hold status")
+
+	active := domain.HoldActive
+	m.holdRepo.On("ListHoldsByEvent", mock.Anything, eventID, &active).Return([]domain.InventoryHold{
+		{ID: uuid.New(), EventID: eventID, Status: domain.HoldActive},
+	}, nil)
+
+	holds, err := service.ListHolds(context.Background(), eventID.String(), "active")
+	require.NoError(t, err)
+	assert.Len(t, holds, 1)
+}
+
+func TestReleaseDueHolds_SkipsHoldsClosedElsewhere(t *testing.T) {
+	service, m := newHoldService(t)
+
+	gone, due, eventID := uuid.New(), uuid.New(), uuid.New()
+
+	m.holdRepo.On("GetDueHolds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{gone, due}, nil)
+	m.holdRepo.On("ReleaseHold", mock.Anything, gone, (*uuid.UUID)(nil), domain.AuditReasonHoldAutoReleased).
+		Return(errors.New("hold is not active")).Once()
+	m.holdRepo.On("ReleaseHold", mock.Anything, due, (*uuid.UUID)(nil), domain.AuditReasonHoldAutoReleased).Return(nil).Once()
+	m.holdRepo.On("GetHold", mock.Anything, due).Return(&domain.InventoryHold{ID: due, EventID: eventID, Status: domain.HoldReleased}, nil)
+	m.seatCache.On("PutSeats", mock.Anything, eventID, []domain.Seat(nil)).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	service.ReleaseDueHolds(context.Background())
+}
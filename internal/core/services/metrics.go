@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+// Booking failure reasons reported to ports.Metrics.
+const (
+	failInvalidRequest  = "invalid_request"
+	failSeatNotFound    = "seat_not_found"
+	failSeatUnavailable = "seat_unavailable"
+	failWrongEvent      = "wrong_event"
+	failLockConflict    = "lock_conflict"
+	failPersistError    = "persist_error"
+	failReserveError    = "reservation_error"
+	failQueueFull       = "queue_full"
+	failPersistDropped  = "reservation_dropped"
+
+	expireHoldTimeout = "hold_expired"
+)
+
+// SetMetrics reports booking behaviour to m. Without this call only the
+// expvar counters are kept.
+func (s *BookingService) SetMetrics(m ports.Metrics) {
+	s.metrics = m
+}
+
+// bookingFailed records and logs a failed booking and returns err unchanged.
+// Failures caused by the request, such as a taken seat, are only logged at
+// debug level because an on-sale produces a great many of them.
+func (s *BookingService) bookingFailed(ctx context.Context, eventID uuid.UUID, reason string, err error) error {
+	s.metrics.BookingFailed(eventID, reason)
+
+	level := slog.LevelDebug
+	switch reason {
+	case failPersistError, failReserveError, failQueueFull:
+		level = slog.LevelError
+	}
+
+	logging.FromContext(ctx).Log(ctx, level, "Booking failed", "reason", reason, "error", err)
+
+	return err
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) BookingCreated(uuid.UUID)              {}
+func (noopMetrics) BookingFailed(uuid.UUID, string)       {}
+func (noopMetrics) BookingExpired(string)                 {}
+func (noopMetrics) BookingDuration(string, time.Duration) {}
+func (noopMetrics) LockConflict(uuid.UUID)                {}
+func (noopMetrics) SeatCacheLookup(bool)                  {}
+func (noopMetrics) SeatCacheEvent(string)                 {}
+func (noopMetrics) ReservationEvent(string)               {}
+func (noopMetrics) CleanupBacklog(int)                    {}
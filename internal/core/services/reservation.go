@@ -0,0 +1,468 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	reservationModeCacheTTL = 10 * time.Second
+	reservationQueueSize    = 10000
+
+	// reservationQueueWait bounds how long a booking waits for room in a full
+	// queue before it is turned away with ErrBookingQueueFull.
+	reservationQueueWait = 50 * time.Millisecond
+
+	// lostReservationGrace is how long a hold may exist without its seat being
+	// locked in Postgres before the reconciler assumes the write was lost.
+	// Holds whose booking is still queued here are never counted as lost,
+	// however long the queue takes.
+	lostReservationGrace = 1 * time.Minute
+)
+
+// ErrBookingQueueFull means the reservation writers are behind. The claim was
+// undone and the client should retry shortly.
+var ErrBookingQueueFull = errors.New("booking queue is full, retry shortly")
+
+// reservationStats is published at /debug/vars and reported to ports.Metrics
+// by reservationEvent:
+//   - reserved, conflicts: Redis claims that succeeded / hit a taken seat
+//   - persisted, persist_failed: asynchronous Postgres writes
+//   - lost_holds_released: holds dropped by the reconciler
+//   - reconciled: events whose seat hash was rebuilt by the reconciler
+//   - reconcile_stale: rebuilds dropped because the hash changed meanwhile
+var reservationStats = expvar.NewMap("reservations")
+
+func (s *BookingService) reservationEvent(name string) {
+	reservationStats.Add(name, 1)
+	s.metrics.ReservationEvent(name)
+}
+
+// queuedReservation is a booking waiting for RunReservationWriter. It carries
+// the span context of the request that made it so the write can link to it.
+type queuedReservation struct {
+	booking *domain.Booking
+	origin  trace.SpanContext
+}
+
+type reservationModeEntry struct {
+	mode     domain.ReservationMode
+	loadedAt time.Time
+}
+
+// EnableReservationMode turns on per-event Redis-first reservations. Events
+// whose reservation_mode is REDIS then claim seats in the reservation store
+// and are written to Postgres by RunReservationWriter. Without this call all
+// events use database locking.
+func (s *BookingService) EnableReservationMode(eventRepo ports.EventRepository, store ports.SeatReservationStore) {
+	s.eventRepo = eventRepo
+	s.reservations = store
+	s.reservationQueue = make(chan queuedReservation, reservationQueueSize)
+	s.pendingWrites = make(map[uuid.UUID]map[uuid.UUID]struct{})
+}
+
+// trackPendingWrite records a booking as queued until finishPendingWrite.
+func (s *BookingService) trackPendingWrite(eventID, bookingID uuid.UUID) {
+	s.pendingWritesMu.Lock()
+	defer s.pendingWritesMu.Unlock()
+
+	bookings, ok := s.pendingWrites[eventID]
+	if !ok {
+		bookings = make(map[uuid.UUID]struct{})
+		s.pendingWrites[eventID] = bookings
+	}
+	bookings[bookingID] = struct{}{}
+}
+
+func (s *BookingService) finishPendingWrite(eventID, bookingID uuid.UUID) {
+	s.pendingWritesMu.Lock()
+	defer s.pendingWritesMu.Unlock()
+
+	delete(s.pendingWrites[eventID], bookingID)
+	if len(s.pendingWrites[eventID]) == 0 {
+		delete(s.pendingWrites, eventID)
+	}
+}
+
+// pendingWritesOf returns a copy of the event's queued or in-flight bookings.
+func (s *BookingService) pendingWritesOf(eventID uuid.UUID) map[uuid.UUID]bool {
+	s.pendingWritesMu.Lock()
+	defer s.pendingWritesMu.Unlock()
+
+	pending := make(map[uuid.UUID]bool, len(s.pendingWrites[eventID]))
+	for bookingID := range s.pendingWrites[eventID] {
+		pending[bookingID] = true
+	}
+
+	return pending
+}
+
+// reservationMode is looked up on every booking, so it is cached briefly.
+// Switching an event's mode therefore takes up to reservationModeCacheTTL.
+func (s *BookingService) reservationMode(ctx context.Context, eventID uuid.UUID) domain.ReservationMode {
+	if s.reservations == nil {
+		return domain.ReservationDatabase
+	}
+
+	if cached, ok := s.reservationModes.Load(eventID); ok {
+		entry := cached.(reservationModeEntry)
+		if time.Since(entry.loadedAt) < reservationModeCacheTTL {
+			return entry.mode
+		}
+	}
+
+	mode := domain.ReservationDatabase
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err == nil {
+		mode = event.ReservationMode
+	} else {
+		ctx := logging.With(ctx, "event_id", eventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to load reservation mode, using database locking", "error", err)
+	}
+
+	s.reservationModes.Store(eventID, reservationModeEntry{mode: mode, loadedAt: time.Now()})
+
+	return mode
+}
+
+func (s *BookingService) reserveInRedis(ctx context.Context, userID uuid.UUID, eventID uuid.UUID, seatIDs []uuid.UUID) (*CreateBookingResponse, error) {
+	bookingID := uuid.New()
+	ctx = logging.With(ctx, "booking_id", bookingID)
+	createdAt := time.Now()
+	expiresAt := createdAt.Add(s.holdDuration)
+
+	conflicts, err := s.reservations.Reserve(ctx, eventID, bookingID, seatIDs, s.holdDuration)
+	if errors.Is(err, ports.ErrReservationNotReady) {
+		if _, err := s.GetAvailableSeats(ctx, eventID.String()); err != nil {
+			return nil, s.bookingFailed(ctx, eventID, failReserveError, errors.New("internal server error: failed to load seats"))
+		}
+
+		conflicts, err = s.reservations.Reserve(ctx, eventID, bookingID, seatIDs, s.holdDuration)
+	}
+
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to reserve seats in Redis", "error", err)
+		return nil, s.bookingFailed(ctx, eventID, failReserveError, errors.New("internal server error: failed to reserve seats"))
+	}
+
+	if len(conflicts) > 0 {
+		s.reservationEvent("conflicts")
+		s.metrics.LockConflict(eventID)
+		return nil, s.bookingFailed(ctx, eventID, failLockConflict, seatUnavailable(conflicts[0].String()))
+	}
+
+	s.reservationEvent("reserved")
+
+	booking := &domain.Booking{
+		ID:        bookingID,
+		UserID:    userID,
+		EventID:   eventID,
+		Status:    domain.BookingPending,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+
+	for _, seatID := range seatIDs {
+		booking.TotalAmount += defaultSeatPrice
+		booking.Items = append(booking.Items, domain.BookingItem{
+			ID:             uuid.New(),
+			BookingID:      bookingID,
+			SeatID:         seatID,
+			PriceAtBooking: defaultSeatPrice,
+		})
+	}
+
+	// Tracked before it is queued, so a writer can't finish it first.
+	s.trackPendingWrite(eventID, bookingID)
+
+	if !s.enqueueReservation(ctx, queuedReservation{booking: booking, origin: trace.SpanContextFromContext(ctx)}) {
+		s.finishPendingWrite(eventID, bookingID)
+
+		// The seats stay out of the available hash until the reconciler
+		// notices the released holds and rebuilds it.
+		if err := s.reservations.Release(context.WithoutCancel(ctx), eventID, bookingID, seatIDs); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to release holds of unqueued booking", "error", err)
+		}
+
+		return nil, s.bookingFailed(ctx, eventID, failQueueFull, ErrBookingQueueFull)
+	}
+
+	s.metrics.BookingCreated(eventID)
+	logging.FromContext(ctx).InfoContext(ctx, "Booking reserved in Redis", "seat_count", len(seatIDs))
+	s.invalidateSeatMap(ctx, eventID)
+
+	return &CreateBookingResponse{
+		BookingID:   bookingID.String(),
+		TotalAmount: booking.TotalAmount,
+		Status:      string(domain.BookingPending),
+		ExpiresAt:   expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// enqueueReservation hands a reservation to the writers, waiting at most
+// reservationQueueWait for room so a backlog sheds load instead of holding
+// requests until they time out.
+func (s *BookingService) enqueueReservation(ctx context.Context, queued queuedReservation) bool {
+	wait := time.NewTimer(reservationQueueWait)
+	defer wait.Stop()
+
+	select {
+	case s.reservationQueue <- queued:
+		return true
+	case <-wait.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// RunReservationWriter persists Redis reservations to Postgres with the given
+// number of workers until ctx is cancelled. Reservations still queued at that
+// point were already confirmed to their callers, so they are persisted before
+// it returns; stop the HTTP server first so the queue can't refill.
+func (s *BookingService) RunReservationWriter(ctx context.Context, workers int) {
+	if s.reservationQueue == nil {
+		return
+	}
+
+	ctx = logging.With(ctx, "worker", "reservation_writer")
+	logger := logging.FromContext(ctx)
+	logger.InfoContext(ctx, "Reservation writer started", "workers", workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case queued := <-s.reservationQueue:
+					s.persistQueued(context.WithoutCancel(ctx), queued)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if pending := len(s.reservationQueue); pending > 0 {
+		logger.InfoContext(ctx, "Draining queued reservations", "count", pending)
+	}
+
+	// The workers have exited, so this is the only receiver left.
+	for len(s.reservationQueue) > 0 {
+		s.persistQueued(context.WithoutCancel(ctx), <-s.reservationQueue)
+	}
+
+	logger.InfoContext(ctx, "Reservation writer stopped")
+}
+
+// persistQueued persists one queued reservation in its own trace, linked to
+// the request that queued it.
+func (s *BookingService) persistQueued(ctx context.Context, queued queuedReservation) {
+	defer s.finishPendingWrite(queued.booking.EventID, queued.booking.ID)
+
+	ctx, span := tracer.Start(ctx, "BookingService.persistReservation",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.Link{SpanContext: queued.origin}),
+		trace.WithAttributes(bookingIDAttr(queued.booking.ID.String()),
+			eventIDAttr(queued.booking.EventID.String()),
+			seatCountAttr(len(queued.booking.Items))))
+	defer span.End()
+
+	ctx = logging.With(ctx,
+		"booking_id", queued.booking.ID,
+		"user_id", queued.booking.UserID,
+		"event_id", queued.booking.EventID)
+
+	s.persistReservation(ctx, queued.booking)
+}
+
+// persistReservation replays a Redis reservation against Postgres using the
+// normal optimistic locks. If Postgres disagrees (the seat was taken outside
+// Redis) the booking is dropped and its holds are released.
+func (s *BookingService) persistReservation(ctx context.Context, booking *domain.Booking) {
+	var lockedSeatIDs []uuid.UUID
+	var seatIDs []uuid.UUID
+	for _, item := range booking.Items {
+		seatIDs = append(seatIDs, item.SeatID)
+	}
+
+	fail := func(err error) {
+		s.rollbackLocks(ctx, lockedSeatIDs, booking.ID, booking.UserID)
+
+		if err := s.reservations.Release(ctx, booking.EventID, booking.ID, seatIDs); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to release holds of dropped booking", "error", err)
+		}
+
+		s.reservationEvent("persist_failed")
+		s.metrics.BookingFailed(booking.EventID, failPersistDropped)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to persist Redis reservation, booking dropped", "error", err)
+	}
+
+	for _, seatID := range seatIDs {
+		seat, err := s.seatRepo.GetByID(ctx, seatID)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		if !seat.IsAvailable() || seat.EventID != booking.EventID {
+			fail(fmt.Errorf("seat %s is %s in the database", seatID, seat.Status))
+			return
+		}
+
+		if err := s.seatRepo.LockSeat(ctx, seat.ID, booking.ID, booking.UserID, seat.Version); err != nil {
+			s.metrics.LockConflict(booking.EventID)
+			fail(err)
+			return
+		}
+
+		lockedSeatIDs = append(lockedSeatIDs, seat.ID)
+	}
+
+	if err := s.bookingRepo.CreateBooking(ctx, booking); err != nil {
+		fail(err)
+		return
+	}
+
+	s.reservationEvent("persisted")
+
+	// A rebuild that read Postgres before this write may have put the seats
+	// back into the cache or the seat map as AVAILABLE.
+	if err := s.seatCache.RemoveSeats(ctx, booking.EventID, seatIDs); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to remove persisted seats from cache", "error", err)
+	}
+	s.invalidateSeatMap(ctx, booking.EventID)
+}
+
+// RunReservationReconciler periodically repairs divergence between Redis and
+// Postgres for every event in Redis reservation mode.
+func (s *BookingService) RunReservationReconciler(ctx context.Context) {
+	if s.reservations == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.reconcileInterval)
+	defer ticker.Stop()
+
+	ctx = logging.With(ctx, "worker", "reservation_reconciler")
+	logger := logging.FromContext(ctx)
+	logger.InfoContext(ctx, "Reservation reconciler started", "interval", s.reconcileInterval.String())
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Reservation reconciler stopped")
+			return
+		case <-ticker.C:
+			s.reconcileReservations(ctx)
+		}
+	}
+}
+
+func (s *BookingService) reconcileReservations(ctx context.Context) {
+	events, err := s.eventRepo.ListByReservationMode(ctx, domain.ReservationRedis)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to list events in Redis reservation mode", "error", err)
+		return
+	}
+
+	for _, event := range events {
+		eventCtx := logging.With(ctx, "event_id", event.ID)
+		if err := s.reconcileEvent(eventCtx, event.ID); err != nil {
+			logging.FromContext(eventCtx).ErrorContext(eventCtx, "Failed to reconcile reservations", "error", err)
+		}
+	}
+}
+
+// reconcileEvent rebuilds the available-seat hash from Postgres, leaving out
+// seats that still have a live hold. Expired holds are dropped, and so are
+// holds whose seat is still AVAILABLE in Postgres well after the write should
+// have happened, unless that write is still queued or running.
+func (s *BookingService) reconcileEvent(ctx context.Context, eventID uuid.UUID) error {
+	generation, err := s.seatCache.SeatGeneration(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	holds, err := s.reservations.Holds(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	// Taken before reading Postgres: a write that finishes after this point
+	// has already locked its seats by the time they are read.
+	pending := s.pendingWritesOf(eventID)
+
+	seats, err := s.seatRepo.GetAvailableSeatsByEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	availableInDB := make(map[uuid.UUID]bool, len(seats))
+	for _, seat := range seats {
+		availableInDB[seat.ID] = true
+	}
+
+	now := time.Now()
+	held := make(map[uuid.UUID]bool, len(holds))
+
+	for _, hold := range holds {
+		lost := availableInDB[hold.SeatID] && !pending[hold.BookingID] && now.Sub(hold.CreatedAt) > lostReservationGrace
+
+		if hold.IsExpired(now) || lost {
+			if err := s.reservations.Release(ctx, eventID, hold.BookingID, []uuid.UUID{hold.SeatID}); err != nil {
+				return err
+			}
+
+			if lost {
+				s.reservationEvent("lost_holds_released")
+			}
+
+			continue
+		}
+
+		held[hold.SeatID] = true
+	}
+
+	available := make([]domain.Seat, 0, len(seats))
+	for _, seat := range seats {
+		if !held[seat.ID] {
+			available = append(available, seat)
+		}
+	}
+
+	domain.SortSeats(available)
+
+	replaced, err := s.seatCache.ReplaceAvailableSeats(ctx, eventID, available, generation)
+	if err != nil {
+		return err
+	}
+
+	if !replaced {
+		// A reservation or release changed the hash meanwhile; the next
+		// run reconciles it.
+		s.reservationEvent("reconcile_stale")
+		return nil
+	}
+
+	s.reservationEvent("reconciled")
+	s.invalidateSeatMap(ctx, eventID)
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateBooking_RedisMode_Conflict(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	mockStore := mocks.NewSeatReservationStore(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mocks.NewSeatCache(t), mocks.NewCache(t))
+	service.EnableReservationMode(mockEventRepo, mockStore)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	seatID := uuid.New()
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&domain.Event{ID: eventID, ReservationMode: domain.ReservationRedis}, nil)
+	mockStore.On("Reserve", mock.Anything, eventID, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{seatID}, 10*time.Minute).Return([]uuid.UUID{seatID}, nil)
+
+	resp, err := service.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  uuid.New().String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{seatID.String()},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, services.ErrSeatUnavailable)
+	mockSeatRepo.AssertNotCalled(t, "LockSeat", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateBooking_RedisMode_PersistsAsynchronously(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	mockStore := mocks.NewSeatReservationStore(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+	service.EnableReservationMode(mockEventRepo, mockStore)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	eventID := uuid.New()
+	seatID := uuid.New()
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&domain.Event{ID: eventID, ReservationMode: domain.ReservationRedis}, nil)
+	mockStore.On("Reserve", mock.Anything, eventID, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{seatID}, 10*time.Minute).Return(nil, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := service.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  userID.String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{seatID.String()},
+	})
+
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+
+	persisted := make(chan struct{}, 1)
+
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(&domain.Seat{ID: seatID, EventID: eventID, Status: domain.SeatAvailable, Version: 4}, nil)
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.AnythingOfType("uuid.UUID"), userID, 4).Return(nil)
+	mockBookingRepo.On("CreateBooking", mock.Anything, mock.AnythingOfType("*domain.Booking")).Return(nil)
+	mockSeatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{seatID}).
+		Run(func(mock.Arguments) { persisted <- struct{}{} }).
+		Return(nil)
+
+	writerCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		service.RunReservationWriter(writerCtx, 1)
+		close(done)
+	}()
+
+	// The seats leave the seat cache only once the booking is written.
+	select {
+	case <-persisted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reservation was not persisted")
+	}
+
+	mockBookingRepo.AssertCalled(t, "CreateBooking", mock.Anything, mock.MatchedBy(func(booking *domain.Booking) bool {
+		return booking.ID.String() == resp.BookingID && booking.TotalAmount == 100000.0
+	}))
+
+	cancel()
+	<-done
+}
+
+func TestRunReservationWriter_DrainsQueueOnShutdown(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	mockStore := mocks.NewSeatReservationStore(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+	service.EnableReservationMode(mockEventRepo, mockStore)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	eventID := uuid.New()
+	seatID := uuid.New()
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&domain.Event{ID: eventID, ReservationMode: domain.ReservationRedis}, nil)
+	mockStore.On("Reserve", mock.Anything, eventID, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{seatID}, 10*time.Minute).Return(nil, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := service.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  userID.String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{seatID.String()},
+	})
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(&domain.Seat{ID: seatID, EventID: eventID, Status: domain.SeatAvailable, Version: 1}, nil)
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.AnythingOfType("uuid.UUID"), userID, 1).Return(nil)
+	mockBookingRepo.On("CreateBooking", mock.Anything, mock.AnythingOfType("*domain.Booking")).Return(nil).Once()
+	mockSeatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{seatID}).Return(nil)
+
+	// The writer starts after shutdown began: the queued reservation must
+	// still be written before it returns.
+	writerCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	service.RunReservationWriter(writerCtx, 1)
+
+	mockBookingRepo.AssertCalled(t, "CreateBooking", mock.Anything, mock.AnythingOfType("*domain.Booking"))
+}
+
+func TestRunReservationReconciler_KeepsQueuedHolds(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
+	mockStore := mocks.NewSeatReservationStore(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mockSeatCache, mockCache)
+	service.Configure(services.BookingConfig{ReconcileInterval: 10 * time.Millisecond})
+	service.EnableReservationMode(mockEventRepo, mockStore)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	queuedSeat := uuid.New()
+	lostSeat := uuid.New()
+	lostBooking := uuid.New()
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&domain.Event{ID: eventID, ReservationMode: domain.ReservationRedis}, nil)
+	mockStore.On("Reserve", mock.Anything, eventID, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{queuedSeat}, 10*time.Minute).Return(nil, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// No writer runs, so the booking stays queued.
+	resp, err := service.CreateBooking(ctx, services.CreateBookingRequest{
+		UserID:  uuid.New().String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{queuedSeat.String()},
+	})
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	queuedBooking := uuid.MustParse(resp.BookingID)
+
+	// Both holds are past the grace period with their seats still AVAILABLE
+	// in Postgres; only the one without a queued write is lost.
+	created := time.Now().Add(-5 * time.Minute)
+	expires := time.Now().Add(5 * time.Minute)
+
+	released := make(chan struct{}, 1)
+
+	mockEventRepo.On("ListByReservationMode", mock.Anything, domain.ReservationRedis).Return([]domain.Event{{ID: eventID}}, nil)
+	mockSeatCache.On("SeatGeneration", mock.Anything, eventID).Return(int64(1), nil)
+	mockStore.On("Holds", mock.Anything, eventID).Return([]domain.SeatHold{
+		{SeatID: queuedSeat, BookingID: queuedBooking, CreatedAt: created, ExpiresAt: expires},
+		{SeatID: lostSeat, BookingID: lostBooking, CreatedAt: created, ExpiresAt: expires},
+	}, nil)
+	mockSeatRepo.On("GetAvailableSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{
+		{ID: queuedSeat, EventID: eventID, Status: domain.SeatAvailable},
+		{ID: lostSeat, EventID: eventID, Status: domain.SeatAvailable},
+	}, nil)
+	mockStore.On("Release", mock.Anything, eventID, lostBooking, []uuid.UUID{lostSeat}).Return(nil)
+	mockSeatCache.On("ReplaceAvailableSeats", mock.Anything, eventID, mock.Anything, int64(1)).
+		Run(func(mock.Arguments) {
+			select {
+			case released <- struct{}{}:
+			default:
+			}
+		}).
+		Return(true, nil)
+
+	reconcilerCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		service.RunReservationReconciler(reconcilerCtx)
+		close(done)
+	}()
+
+	select {
+	case <-released:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reservations were not reconciled")
+	}
+
+	cancel()
+	<-done
+
+	mockStore.AssertNotCalled(t, "Release", mock.Anything, eventID, queuedBooking, mock.Anything)
+	mockSeatCache.AssertCalled(t, "ReplaceAvailableSeats", mock.Anything, eventID, []domain.Seat{
+		{ID: lostSeat, EventID: eventID, Status: domain.SeatAvailable},
+	}, int64(1))
+}
+
+func TestCreateBooking_RedisMode_FullQueueShedsLoad(t *testing.T) {
+	mockEventRepo := mocks.NewEventRepository(t)
+	mockStore := mocks.NewSeatReservationStore(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mocks.NewSeatRepository(t), mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mockCache)
+	service.EnableReservationMode(mockEventRepo, mockStore)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&domain.Event{ID: eventID, ReservationMode: domain.ReservationRedis}, nil)
+	mockStore.On("Reserve", mock.Anything, eventID, mock.Anything, mock.Anything, 10*time.Minute).Return(nil, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	book := func() (*services.CreateBookingResponse, error) {
+		return service.CreateBooking(ctx, services.CreateBookingRequest{
+			UserID:  uuid.New().String(),
+			EventID: eventID.String(),
+			SeatIDs: []string{uuid.New().String()},
+		})
+	}
+
+	mockStore.On("Release", mock.Anything, eventID, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// No writer runs, so the queue fills up and the next booking is turned
+	// away without waiting for the request to time out.
+	for queued := 0; ; queued++ {
+		start := time.Now()
+		resp, err := book()
+		if err == nil {
+			continue
+		}
+
+		assert.Equal(t, 10000, queued)
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, services.ErrBookingQueueFull)
+		assert.Less(t, time.Since(start), time.Second)
+		break
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	seatRebuildWaitTimeout = 2 * time.Second
+	seatRebuildPollEvery   = 50 * time.Millisecond
+)
+
+// seatCacheStats is published at /debug/vars. Everything but hits and misses
+// is also reported to ports.Metrics by seatCacheEvent:
+//   - hits, misses: reads that were / weren't served from the seat cache
+//   - coalesced: misses that shared another in-process caller's rebuild
+//   - rebuilds: database scans written back to the cache by this replica
+//   - stale_rebuilds: scans dropped because the seats changed during the scan
+//   - rebuild_lock_waits: misses that waited for another replica's rebuild
+//   - rebuild_wait_timeouts: waits that gave up and read Postgres directly
+var seatCacheStats = expvar.NewMap("seat_cache")
+
+func (s *BookingService) seatCacheEvent(name string) {
+	seatCacheStats.Add(name, 1)
+	s.metrics.SeatCacheEvent(name)
+}
+
+// refreshReleasedSeats puts the seats of a released booking back into the
+// seat cache, re-reading them so the cached version matches the database.
+func (s *BookingService) refreshReleasedSeats(ctx context.Context, booking *domain.Booking) {
+	var released []domain.Seat
+
+	for _, item := range booking.Items {
+		seat, err := s.seatRepo.GetByID(ctx, item.SeatID)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to reload released seat", "seat_id", item.SeatID, "error", err)
+			continue
+		}
+
+		if seat.IsAvailable() {
+			seat.LockedByBookingID = nil
+			seat.LockedAt = nil
+			released = append(released, *seat)
+		}
+	}
+
+	if err := s.seatCache.PutSeats(ctx, booking.EventID, released); err != nil {
+		ctx := logging.With(ctx, "event_id", booking.EventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to put released seats into cache", "error", err)
+	}
+
+	s.invalidateSeatMap(ctx, booking.EventID)
+}
+
+func (s *BookingService) GetAvailableSeats(ctx context.Context, eventIDStr string) ([]domain.Seat, error) {
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	seats, ok := s.seatCache.AvailableSeats(ctx, eventID)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("seat_cache.hit", ok))
+
+	if ok {
+		seatCacheStats.Add("hits", 1)
+		s.metrics.SeatCacheLookup(true)
+		return orEmpty(seats), nil
+	}
+
+	seatCacheStats.Add("misses", 1)
+	s.metrics.SeatCacheLookup(false)
+
+	// Concurrent misses in this process share one load. The load must not be
+	// cancelled just because the caller that started it went away.
+	loadCtx := context.WithoutCancel(ctx)
+	result, err, shared := s.seatLoads.Do(eventID.String(), func() (interface{}, error) {
+		return s.loadAvailableSeats(loadCtx, eventID)
+	})
+
+	if shared {
+		s.seatCacheEvent("coalesced")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return orEmpty(result.([]domain.Seat)), nil
+}
+
+// SeatResponse is an available seat as listed under /v1. The unversioned
+// GET /seats still returns domain.Seat as is.
+type SeatResponse struct {
+	SeatID     string `json:"seat_id"`
+	EventID    string `json:"event_id"`
+	TierID     string `json:"tier_id"`
+	Section    string `json:"section"`
+	RowNumber  string `json:"row_number"`
+	SeatNumber string `json:"seat_number"`
+	Status     string `json:"status"`
+}
+
+// ListAvailableSeats is GetAvailableSeats in the /v1 response shape.
+func (s *BookingService) ListAvailableSeats(ctx context.Context, eventIDStr string) ([]SeatResponse, error) {
+	seats, err := s.GetAvailableSeats(ctx, eventIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]SeatResponse, 0, len(seats))
+	for _, seat := range seats {
+		resp = append(resp, SeatResponse{
+			SeatID:     seat.ID.String(),
+			EventID:    seat.EventID.String(),
+			TierID:     seat.TierID.String(),
+			Section:    seat.Section,
+			RowNumber:  seat.RowNumber,
+			SeatNumber: seat.SeatNumber,
+			Status:     string(seat.Status),
+		})
+	}
+
+	return resp, nil
+}
+
+// orEmpty makes an event with no seats left list as [] rather than null.
+func orEmpty(seats []domain.Seat) []domain.Seat {
+	if seats == nil {
+		return []domain.Seat{}
+	}
+
+	return seats
+}
+
+// loadAvailableSeats rebuilds the seat hash from Postgres. Across replicas only
+// the holder of the rebuild lock scans the table; the others wait for its
+// result and only fall back to their own read if it takes too long.
+func (s *BookingService) loadAvailableSeats(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, error) {
+	acquired, release := s.seatCache.LockRebuild(ctx, eventID)
+	defer release()
+
+	if !acquired {
+		s.seatCacheEvent("rebuild_lock_waits")
+
+		if seats, ok := s.waitForRebuild(ctx, eventID); ok {
+			return seats, nil
+		}
+
+		s.seatCacheEvent("rebuild_wait_timeouts")
+	}
+
+	// The generation is read before the scan, so a booking or release that
+	// lands while Postgres is read makes the rebuild drop its snapshot.
+	var generation int64
+	if acquired {
+		var err error
+		if generation, err = s.seatCache.SeatGeneration(ctx, eventID); err != nil {
+			ctx := logging.With(ctx, "event_id", eventID)
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to read seat cache generation", "error", err)
+			acquired = false
+		}
+	}
+
+	seats, err := s.seatRepo.GetAvailableSeatsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	domain.SortSeats(seats)
+
+	if acquired {
+		replaced, err := s.seatCache.ReplaceAvailableSeats(ctx, eventID, seats, generation)
+		if err != nil {
+			ctx := logging.With(ctx, "event_id", eventID)
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to rebuild seat cache", "error", err)
+		} else if !replaced {
+			s.seatCacheEvent("stale_rebuilds")
+		} else {
+			s.seatCacheEvent("rebuilds")
+		}
+	}
+
+	return seats, nil
+}
+
+// waitForRebuild polls the cache until another replica has finished building
+// it or seatRebuildWaitTimeout passes.
+func (s *BookingService) waitForRebuild(ctx context.Context, eventID uuid.UUID) ([]domain.Seat, bool) {
+	deadline := time.Now().Add(seatRebuildWaitTimeout)
+
+	for {
+		if seats, ok := s.seatCache.AvailableSeats(ctx, eventID); ok {
+			return seats, true
+		}
+
+		if time.Now().After(deadline) {
+			return nil, false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(seatRebuildPollEvery):
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+// SeatUpdate is one message of a seat watch. The first update is a snapshot
+// of every seat of the event; later ones hold only seats whose status changed.
+type SeatUpdate struct {
+	Snapshot bool
+	Seats    []domain.Seat
+}
+
+// WatchSeats calls send with the seats of an event, then again whenever seats
+// change status. It returns nil once ctx is done, or the first error from
+// send.
+//
+// All watches of an event in this process share one poller, started by the
+// first watch with its interval and stopped when the last one ends. Each poll
+// only reads the seat map's ETag, which is cached and shared by all replicas,
+// so the seats are reloaded only after something changed.
+func (s *BookingService) WatchSeats(ctx context.Context, eventIDStr string, interval time.Duration, send func(SeatUpdate) error) error {
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return errors.New("invalid event id")
+	}
+
+	poller, err := s.joinSeatPoller(ctx, eventID, interval)
+	if err != nil {
+		return err
+	}
+	defer s.leaveSeatPoller(poller)
+
+	watcher, seats := poller.subscribe()
+	defer poller.unsubscribe(watcher)
+
+	if err := send(SeatUpdate{Snapshot: true, Seats: seats}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-watcher.notify:
+		}
+
+		changed := watcher.take()
+		if len(changed) == 0 {
+			continue
+		}
+
+		if err := send(SeatUpdate{Seats: changed}); err != nil {
+			return err
+		}
+	}
+}
+
+// seatPoller follows one event's seats for every watch of it.
+type seatPoller struct {
+	eventID  uuid.UUID
+	interval time.Duration
+
+	// refs counts the watches using the poller; guarded by
+	// BookingService.seatWatchMu.
+	refs int
+	stop context.CancelFunc
+
+	// ready is closed once the first load is done; err is its result.
+	ready chan struct{}
+	err   error
+
+	// etag is only used by the polling goroutine.
+	etag string
+
+	mu       sync.Mutex
+	seats    []domain.Seat
+	known    map[uuid.UUID]domain.SeatStatus
+	watchers map[*seatWatcher]struct{}
+}
+
+// seatWatcher collects the changes a watch has not sent yet. A slow stream
+// only delays itself: changes to the same seat are merged while it catches up.
+type seatWatcher struct {
+	notify chan struct{}
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]domain.Seat
+}
+
+// joinSeatPoller returns the event's poller, starting one if needed, once it
+// has loaded the seats.
+func (s *BookingService) joinSeatPoller(ctx context.Context, eventID uuid.UUID, interval time.Duration) (*seatPoller, error) {
+	s.seatWatchMu.Lock()
+	poller, ok := s.seatWatches[eventID]
+	if !ok {
+		// The poller outlives the watch that started it, so it gets its own
+		// context rather than the request's.
+		pollCtx, stop := context.WithCancel(context.Background())
+		poller = &seatPoller{
+			eventID:  eventID,
+			interval: interval,
+			stop:     stop,
+			ready:    make(chan struct{}),
+			watchers: make(map[*seatWatcher]struct{}),
+		}
+		s.seatWatches[eventID] = poller
+
+		go s.runSeatPoller(pollCtx, poller)
+	}
+	poller.refs++
+	s.seatWatchMu.Unlock()
+
+	select {
+	case <-poller.ready:
+	case <-ctx.Done():
+		s.leaveSeatPoller(poller)
+		return nil, ctx.Err()
+	}
+
+	if poller.err != nil {
+		s.leaveSeatPoller(poller)
+		return nil, poller.err
+	}
+
+	return poller, nil
+}
+
+// leaveSeatPoller stops the poller when its last watch leaves. A poller whose
+// first load failed is dropped the same way, so the next watch tries again.
+func (s *BookingService) leaveSeatPoller(poller *seatPoller) {
+	s.seatWatchMu.Lock()
+	defer s.seatWatchMu.Unlock()
+
+	poller.refs--
+	if poller.refs == 0 {
+		delete(s.seatWatches, poller.eventID)
+		poller.stop()
+	}
+}
+
+func (s *BookingService) runSeatPoller(ctx context.Context, poller *seatPoller) {
+	poller.err = s.loadSeatPoller(ctx, poller)
+	close(poller.ready)
+
+	if poller.err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(poller.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.pollSeats(ctx, poller)
+	}
+}
+
+func (s *BookingService) loadSeatPoller(ctx context.Context, poller *seatPoller) error {
+	seatMap, err := s.GetSeatMap(ctx, poller.eventID.String(), false)
+	if err != nil {
+		return err
+	}
+
+	seats, err := s.seatRepo.GetSeatsByEvent(ctx, poller.eventID)
+	if err != nil {
+		return err
+	}
+
+	poller.etag = seatMap.ETag
+	poller.seats = seats
+	poller.known = make(map[uuid.UUID]domain.SeatStatus, len(seats))
+	for _, seat := range seats {
+		poller.known[seat.ID] = seat.Status
+	}
+
+	return nil
+}
+
+// pollSeats reloads the seats if the seat map changed and hands the seats
+// whose status changed to every watcher.
+func (s *BookingService) pollSeats(ctx context.Context, poller *seatPoller) {
+	seatMap, err := s.GetSeatMap(ctx, poller.eventID.String(), false)
+	if err != nil || seatMap.ETag == poller.etag {
+		s.logWatchError(ctx, poller.eventID, err)
+		return
+	}
+
+	seats, err := s.seatRepo.GetSeatsByEvent(ctx, poller.eventID)
+	if err != nil {
+		s.logWatchError(ctx, poller.eventID, err)
+		return
+	}
+
+	poller.etag = seatMap.ETag
+
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+
+	var changed []domain.Seat
+	for _, seat := range seats {
+		if status, ok := poller.known[seat.ID]; !ok || status != seat.Status {
+			poller.known[seat.ID] = seat.Status
+			changed = append(changed, seat)
+		}
+	}
+
+	poller.seats = seats
+
+	for watcher := range poller.watchers {
+		watcher.push(changed)
+	}
+}
+
+// subscribe registers a watcher and returns the seats it starts from. Both
+// happen under the poller's lock, so the watcher gets every change after its
+// snapshot.
+func (p *seatPoller) subscribe() (*seatWatcher, []domain.Seat) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	watcher := &seatWatcher{
+		notify:  make(chan struct{}, 1),
+		pending: make(map[uuid.UUID]domain.Seat),
+	}
+	p.watchers[watcher] = struct{}{}
+
+	return watcher, slices.Clone(p.seats)
+}
+
+func (p *seatPoller) unsubscribe(watcher *seatWatcher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.watchers, watcher)
+}
+
+func (w *seatWatcher) push(changed []domain.Seat) {
+	if len(changed) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	for _, seat := range changed {
+		w.pending[seat.ID] = seat
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the pending changes in seat order and clears them.
+func (w *seatWatcher) take() []domain.Seat {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	changed := make([]domain.Seat, 0, len(w.pending))
+	for _, seat := range w.pending {
+		changed = append(changed, seat)
+	}
+	clear(w.pending)
+
+	domain.SortSeats(changed)
+
+	return changed
+}
+
+// logWatchError logs a failed poll; the poller carries on and retries on the
+// next tick.
+func (s *BookingService) logWatchError(ctx context.Context, eventID uuid.UUID, err error) {
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	ctx = logging.With(ctx, "event_id", eventID)
+	logging.FromContext(ctx).WarnContext(ctx, "Seat watch poll failed", "error", err)
+}
@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchSeats_SendsSnapshotThenChanges(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mockCache)
+
+	eventID := uuid.New()
+	first := domain.Seat{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "1", Status: domain.SeatAvailable}
+	second := domain.Seat{ID: uuid.New(), EventID: eventID, Section: "A", RowNumber: "1", SeatNumber: "2", Status: domain.SeatAvailable}
+	locked := second
+	locked.Status = domain.SeatLocked
+
+	mockCache.On("Get", mock.Anything, mock.Anything).Return(nil, ports.ErrCacheMiss)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockSeatRepo.On("GetTiersByEvent", mock.Anything, eventID).Return([]domain.PricingTier{}, nil)
+	// The seat map and the snapshot read the seats once each, then the first
+	// poll sees a new seat map and reloads.
+	mockSeatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{first, second}, nil).Twice()
+	mockSeatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{first, locked}, nil)
+
+	stop := errors.New("stop watching")
+	var updates []services.SeatUpdate
+
+	err := service.WatchSeats(context.Background(), eventID.String(), time.Millisecond, func(update services.SeatUpdate) error {
+		updates = append(updates, update)
+		if len(updates) == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	require.ErrorIs(t, err, stop)
+	assert.True(t, updates[0].Snapshot)
+	assert.Len(t, updates[0].Seats, 2)
+	assert.False(t, updates[1].Snapshot)
+	assert.Equal(t, []domain.Seat{locked}, updates[1].Seats)
+}
+
+func TestWatchSeats_StopsWithContext(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mockCache)
+
+	eventID := uuid.New()
+	seats := []domain.Seat{{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}}
+
+	mockCache.On("Get", mock.Anything, mock.Anything).Return(nil, ports.ErrCacheMiss)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockSeatRepo.On("GetTiersByEvent", mock.Anything, eventID).Return([]domain.PricingTier{}, nil)
+	mockSeatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return(seats, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	sent := 0
+	err := service.WatchSeats(ctx, eventID.String(), time.Millisecond, func(services.SeatUpdate) error {
+		sent++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent, "nothing changed after the snapshot")
+}
+
+func TestWatchSeats_RejectsUnknownEvent(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mockCache)
+
+	eventID := uuid.New()
+	mockCache.On("Get", mock.Anything, mock.Anything).Return(nil, ports.ErrCacheMiss)
+	mockSeatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return([]domain.Seat{}, nil)
+
+	err := service.WatchSeats(context.Background(), eventID.String(), time.Millisecond, func(services.SeatUpdate) error {
+		t.Fatal("nothing should be sent")
+		return nil
+	})
+
+	assert.EqualError(t, err, "event not found")
+	assert.EqualError(t, service.WatchSeats(context.Background(), "nope", time.Millisecond, nil), "invalid event id")
+}
+
+func TestWatchSeats_SharesOnePollerPerEvent(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mockCache)
+
+	eventID := uuid.New()
+	seats := []domain.Seat{{ID: uuid.New(), EventID: eventID, Status: domain.SeatAvailable}}
+
+	mockCache.On("Get", mock.Anything, mock.Anything).Return(nil, ports.ErrCacheMiss)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockSeatRepo.On("GetTiersByEvent", mock.Anything, eventID).Return([]domain.PricingTier{}, nil)
+	// Both watches share the poller's seat map and snapshot reads; a watch
+	// after they have ended starts a new poller.
+	mockSeatRepo.On("GetSeatsByEvent", mock.Anything, eventID).Return(seats, nil).Times(4)
+
+	watch := func(ctx context.Context, snapshots chan<- services.SeatUpdate) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- service.WatchSeats(ctx, eventID.String(), time.Hour, func(update services.SeatUpdate) error {
+				snapshots <- update
+				return nil
+			})
+		}()
+		return done
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	snapshots := make(chan services.SeatUpdate, 3)
+
+	first := watch(ctx, snapshots)
+	assert.Equal(t, seats, (<-snapshots).Seats)
+	second := watch(ctx, snapshots)
+	assert.Equal(t, seats, (<-snapshots).Seats)
+
+	cancel()
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	third := watch(ctx, snapshots)
+	assert.Equal(t, seats, (<-snapshots).Seats)
+
+	cancel()
+	require.NoError(t, <-third)
+}
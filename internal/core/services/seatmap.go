@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+const seatMapCacheTTL = 5 * time.Second
+
+// seatStatusCodes are the single-letter codes used in run-length status strings.
+var seatStatusCodes = map[domain.SeatStatus]string{
+	domain.SeatAvailable:   "A",
+	domain.SeatLocked:      "L",
+	domain.SeatBooked:      "B",
+	domain.SeatSold:        "S",
+	domain.SeatUnavailable: "U",
+}
+
+type SeatMapTier struct {
+	Index int     `json:"index"`
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// SeatMapRowResponse describes one row in a compact form:
+//   - Seats lists seat numbers, collapsing consecutive numbers ("1-20,22").
+//   - Status is run-length encoded status codes ("18A2L").
+//   - Tiers is run-length encoded tier indexes ("10*0,10*1"), -1 for unknown.
+//   - IDs holds seat IDs in the same order and is only filled on request.
+type SeatMapRowResponse struct {
+	Name   string   `json:"name"`
+	Seats  string   `json:"seats"`
+	Status string   `json:"status"`
+	Tiers  string   `json:"tiers"`
+	IDs    []string `json:"ids,omitempty"`
+}
+
+type SeatMapSectionResponse struct {
+	Name string               `json:"name"`
+	Rows []SeatMapRowResponse `json:"rows"`
+}
+
+type SeatMapResponse struct {
+	EventID     string                   `json:"event_id"`
+	TotalSeats  int                      `json:"total_seats"`
+	StatusCodes map[string]string        `json:"status_codes"`
+	Tiers       []SeatMapTier            `json:"tiers"`
+	Sections    []SeatMapSectionResponse `json:"sections"`
+}
+
+// EncodedSeatMap is a serialized seat map together with its strong ETag.
+type EncodedSeatMap struct {
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
+func (s *BookingService) GetSeatMap(ctx context.Context, eventIDStr string, includeIDs bool) (*EncodedSeatMap, error) {
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	cacheKey := seatMapCacheKey(eventIDStr, includeIDs)
+
+	cachedData, err := s.cache.Get(ctx, cacheKey)
+	if err == nil {
+		var encoded EncodedSeatMap
+		if err := json.Unmarshal(cachedData, &encoded); err == nil {
+			return &encoded, nil
+		}
+	}
+
+	seats, err := s.seatRepo.GetSeatsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seats) == 0 {
+		return nil, errors.New("event not found")
+	}
+
+	tiers, err := s.seatRepo.GetTiersByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(encodeSeatMap(domain.BuildSeatMap(eventID, seats, tiers), includeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	encoded := &EncodedSeatMap{
+		ETag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Body: body,
+	}
+
+	dataToCache, _ := json.Marshal(encoded)
+	if err := s.cache.Set(ctx, cacheKey, dataToCache, seatMapCacheTTL); err != nil {
+		ctx := logging.With(ctx, "event_id", eventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to cache seat map", "error", err)
+	}
+
+	return encoded, nil
+}
+
+func seatMapCacheKey(eventID string, includeIDs bool) string {
+	if includeIDs {
+		return fmt.Sprintf("seatmap:%s:ids", eventID)
+	}
+
+	return fmt.Sprintf("seatmap:%s", eventID)
+}
+
+func (s *BookingService) invalidateSeatMap(ctx context.Context, eventID uuid.UUID) {
+	invalidateSeatMap(ctx, s.cache, eventID)
+}
+
+// invalidateSeatMap drops both cached encodings of an event's seat map.
+func invalidateSeatMap(ctx context.Context, cache ports.Cache, eventID uuid.UUID) {
+	if err := cache.Delete(ctx, seatMapCacheKey(eventID.String(), false), seatMapCacheKey(eventID.String(), true)); err != nil {
+		ctx := logging.With(ctx, "event_id", eventID)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to invalidate seat map cache", "error", err)
+	}
+}
+
+func encodeSeatMap(seatMap domain.SeatMap, includeIDs bool) SeatMapResponse {
+	resp := SeatMapResponse{
+		EventID:     seatMap.EventID.String(),
+		StatusCodes: make(map[string]string, len(seatStatusCodes)),
+		Tiers:       make([]SeatMapTier, 0, len(seatMap.Tiers)),
+		Sections:    make([]SeatMapSectionResponse, 0, len(seatMap.Sections)),
+	}
+
+	for status, code := range seatStatusCodes {
+		resp.StatusCodes[code] = string(status)
+	}
+
+	tierIndex := make(map[uuid.UUID]int, len(seatMap.Tiers))
+	for i, tier := range seatMap.Tiers {
+		tierIndex[tier.ID] = i
+		resp.Tiers = append(resp.Tiers, SeatMapTier{
+			Index: i,
+			ID:    tier.ID.String(),
+			Name:  tier.Name,
+			Price: tier.Price,
+		})
+	}
+
+	for _, section := range seatMap.Sections {
+		sectionResp := SeatMapSectionResponse{
+			Name: section.Name,
+			Rows: make([]SeatMapRowResponse, 0, len(section.Rows)),
+		}
+
+		for _, row := range section.Rows {
+			numbers := make([]string, len(row.Seats))
+			statuses := make([]string, len(row.Seats))
+			tiers := make([]string, len(row.Seats))
+
+			rowResp := SeatMapRowResponse{Name: row.Name}
+
+			for i, seat := range row.Seats {
+				numbers[i] = seat.SeatNumber
+
+				code, ok := seatStatusCodes[seat.Status]
+				if !ok {
+					code = "U"
+				}
+				statuses[i] = code
+
+				index, ok := tierIndex[seat.TierID]
+				if !ok {
+					index = -1
+				}
+				tiers[i] = strconv.Itoa(index)
+
+				if includeIDs {
+					rowResp.IDs = append(rowResp.IDs, seat.ID.String())
+				}
+			}
+
+			rowResp.Seats = compressSeatNumbers(numbers)
+			rowResp.Status = runLength(statuses, "")
+			rowResp.Tiers = runLength(tiers, "*")
+
+			sectionResp.Rows = append(sectionResp.Rows, rowResp)
+			resp.TotalSeats += len(row.Seats)
+		}
+
+		resp.Sections = append(resp.Sections, sectionResp)
+	}
+
+	return resp
+}
+
+// runLength encodes values as "<count><sep><value>" runs. With an empty
+// separator the runs are concatenated ("18A2L"); otherwise they are joined
+// with commas ("10*0,10*1").
+func runLength(values []string, sep string) string {
+	var runs []string
+
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+
+		runs = append(runs, strconv.Itoa(j-i)+sep+values[i])
+		i = j
+	}
+
+	if sep == "" {
+		return strings.Join(runs, "")
+	}
+
+	return strings.Join(runs, ",")
+}
+
+// compressSeatNumbers collapses consecutive numeric seat numbers into ranges,
+// e.g. ["1","2","3","5","A"] becomes "1-3,5,A".
+func compressSeatNumbers(numbers []string) string {
+	var parts []string
+
+	for i := 0; i < len(numbers); {
+		start, ok := plainNumber(numbers[i])
+		if !ok {
+			parts = append(parts, numbers[i])
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(numbers) {
+			n, ok := plainNumber(numbers[j])
+			if !ok || n != start+(j-i) {
+				break
+			}
+			j++
+		}
+
+		if j-i > 1 {
+			parts = append(parts, numbers[i]+"-"+numbers[j-1])
+		} else {
+			parts = append(parts, numbers[i])
+		}
+
+		i = j
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// plainNumber reports whether s is a number without sign or leading zeros, so
+// that a range like "1-3" expands back to exactly the original strings.
+func plainNumber(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || strconv.Itoa(n) != s || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
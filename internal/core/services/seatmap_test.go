@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSeatMap_CompactEncoding(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	ctx := context.Background()
+	eventID := uuid.New()
+	vip := domain.PricingTier{ID: uuid.New(), EventID: eventID, Name: "VIP", Price: 5000000}
+	regular := domain.PricingTier{ID: uuid.New(), EventID: eventID, Name: "Regular", Price: 1000000}
+
+	seat := func(row, number string, status domain.SeatStatus, tier domain.PricingTier) domain.Seat {
+		return domain.Seat{ID: uuid.New(), EventID: eventID, TierID: tier.ID, Section: "A", RowNumber: row, SeatNumber: number, Status: status}
+	}
+
+	seats := []domain.Seat{
+		seat("10", "1", domain.SeatAvailable, regular),
+		seat("2", "10", domain.SeatBooked, vip),
+		seat("2", "9", domain.SeatAvailable, vip),
+		seat("2", "1", domain.SeatAvailable, vip),
+		seat("2", "2", domain.SeatAvailable, vip),
+		seat("2", "3", domain.SeatLocked, regular),
+	}
+
+	cacheKey := "seatmap:" + eventID.String()
+	mockCache.On("Get", ctx, cacheKey).Return(nil, ports.ErrCacheMiss)
+	mockCache.On("Set", ctx, cacheKey, mock.Anything, 5*time.Second).Return(nil)
+
+	mockSeatRepo.On("GetSeatsByEvent", ctx, eventID).Return(seats, nil)
+	mockSeatRepo.On("GetTiersByEvent", ctx, eventID).Return([]domain.PricingTier{vip, regular}, nil)
+
+	encoded, err := service.GetSeatMap(ctx, eventID.String(), false)
+
+	assert.NoError(t, err)
+	if !assert.NotNil(t, encoded) {
+		return
+	}
+
+	assert.NotEmpty(t, encoded.ETag)
+
+	var resp services.SeatMapResponse
+	assert.NoError(t, json.Unmarshal(encoded.Body, &resp))
+
+	assert.Equal(t, 6, resp.TotalSeats)
+	if assert.Len(t, resp.Sections, 1) && assert.Len(t, resp.Sections[0].Rows, 2) {
+		row2 := resp.Sections[0].Rows[0]
+		assert.Equal(t, "2", row2.Name)
+		assert.Equal(t, "1-3,9-10", row2.Seats)
+		assert.Equal(t, "2A1L1A1B", row2.Status)
+		assert.Equal(t, "2*0,1*1,2*0", row2.Tiers)
+		assert.Empty(t, row2.IDs)
+
+		assert.Equal(t, "10", resp.Sections[0].Rows[1].Name)
+	}
+}
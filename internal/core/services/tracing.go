@@ -0,0 +1,39 @@
+package services
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer uses the global provider; it records nothing until the application
+// installs one.
+var tracer = otel.Tracer("github.com/srgjo27/scalable_ticket/internal/core/services")
+
+func eventIDAttr(id string) attribute.KeyValue {
+	return attribute.String("event.id", id)
+}
+
+func bookingIDAttr(id string) attribute.KeyValue {
+	return attribute.String("booking.id", id)
+}
+
+func holdIDAttr(id string) attribute.KeyValue {
+	return attribute.String("hold.id", id)
+}
+
+func seatCountAttr(n int) attribute.KeyValue {
+	return attribute.Int("booking.seat_count", n)
+}
+
+// endSpan records err, if any, and ends the span. Deferred as
+// defer func() { endSpan(span, err) }() with a named error result.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxSeatsPerBooking caps the distinct seats one booking may hold.
+const MaxSeatsPerBooking = 10
+
+// FieldError is one problem with one field of a request. Field is the JSON
+// name, with an index for list elements, e.g. "seat_ids[2]".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports every problem found in a request at once, so
+// clients can fix them in one go. Its message starts with "invalid request".
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		problems = append(problems, f.Field+" "+f.Message)
+	}
+
+	return "invalid request: " + strings.Join(problems, "; ")
+}
+
+// validator collects field errors while a request is checked.
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// uuid parses a required UUID field, recording a problem and returning
+// uuid.Nil when it is missing or malformed.
+func (v *validator) uuid(field, value string) uuid.UUID {
+	if value == "" {
+		v.add(field, "is required")
+		return uuid.Nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		v.add(field, "must be a UUID")
+		return uuid.Nil
+	}
+
+	return id
+}
+
+// seatIDs parses a required list of seat IDs, dropping repeats, and checks
+// that at most max distinct seats are listed.
+func (v *validator) seatIDs(field string, values []string, max int) []uuid.UUID {
+	if len(values) == 0 {
+		v.add(field, "must list at least one seat")
+	}
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(values))
+	for i, value := range values {
+		id := v.uuid(fmt.Sprintf("%s[%d]", field, i), value)
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) > max {
+		v.add(field, "must list at most %d seats, got %d", max, len(ids))
+	}
+
+	return ids
+}
+
+// text checks a required free-text field of at most max characters and
+// returns it trimmed.
+func (v *validator) text(field, value string, max int) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		v.add(field, "is required")
+	} else if n := utf8.RuneCountInString(value); n > max {
+		v.add(field, "must be at most %d characters, got %d", max, n)
+	}
+
+	return value
+}
+
+// err returns a *ValidationError listing the problems, or nil if there are
+// none.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.fields}
+}
+
+// validBooking is a CreateBookingRequest that passed Validate.
+type validBooking struct {
+	userID  uuid.UUID
+	eventID uuid.UUID
+	seatIDs []uuid.UUID
+}
+
+// Validate checks a booking request, returning a *ValidationError that lists
+// every problem. Seats listed more than once count once.
+func (r CreateBookingRequest) Validate() error {
+	_, err := r.validate()
+	return err
+}
+
+func (r CreateBookingRequest) validate() (validBooking, error) {
+	var v validator
+
+	booking := validBooking{
+		userID:  v.uuid("user_id", r.UserID),
+		eventID: v.uuid("event_id", r.EventID),
+		seatIDs: v.seatIDs("seat_ids", r.SeatIDs, MaxSeatsPerBooking),
+	}
+
+	return booking, v.err()
+}
@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/srgjo27/scalable_ticket/internal/core/domain"
+	"github.com/srgjo27/scalable_ticket/internal/core/ports/mocks"
+	"github.com/srgjo27/scalable_ticket/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBookingRequest_ValidateReportsEveryField(t *testing.T) {
+	err := services.CreateBookingRequest{
+		EventID: "not-a-uuid",
+		SeatIDs: []string{uuid.NewString(), "A1", ""},
+	}.Validate()
+
+	var invalid *services.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []services.FieldError{
+		{Field: "user_id", Message: "is required"},
+		{Field: "event_id", Message: "must be a UUID"},
+		{Field: "seat_ids[1]", Message: "must be a UUID"},
+		{Field: "seat_ids[2]", Message: "is required"},
+	}, invalid.Fields)
+	assert.True(t, strings.HasPrefix(err.Error(), "invalid request: user_id is required; event_id must be a UUID"))
+
+	err = services.CreateBookingRequest{UserID: uuid.NewString(), EventID: uuid.NewString()}.Validate()
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []services.FieldError{{Field: "seat_ids", Message: "must list at least one seat"}}, invalid.Fields)
+}
+
+func TestCreateBookingRequest_ValidateCapsDistinctSeats(t *testing.T) {
+	req := services.CreateBookingRequest{UserID: uuid.NewString(), EventID: uuid.NewString()}
+	for range services.MaxSeatsPerBooking {
+		req.SeatIDs = append(req.SeatIDs, uuid.NewString())
+	}
+
+	// Repeating a seat, even in another case, doesn't count against the cap.
+	req.SeatIDs = append(req.SeatIDs, strings.ToUpper(req.SeatIDs[0]))
+	assert.NoError(t, req.Validate())
+
+	req.SeatIDs = append(req.SeatIDs, uuid.NewString())
+	var invalid *services.ValidationError
+	require.ErrorAs(t, req.Validate(), &invalid)
+	assert.Equal(t, []services.FieldError{
+		{Field: "seat_ids", Message: fmt.Sprintf("must list at most %d seats, got %d", services.MaxSeatsPerBooking, services.MaxSeatsPerBooking+1)},
+	}, invalid.Fields)
+}
+
+func TestCreateBooking_InvalidRequestTouchesNothing(t *testing.T) {
+	mockMetrics := mocks.NewMetrics(t)
+
+	service := services.NewBookingService(mocks.NewSeatRepository(t), mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mocks.NewCache(t))
+	service.SetMetrics(mockMetrics)
+
+	mockMetrics.On("BookingFailed", uuid.Nil, "invalid_request").Once()
+	mockMetrics.On("BookingDuration", "failed", mock.AnythingOfType("time.Duration")).Once()
+
+	_, err := service.CreateBooking(context.Background(), services.CreateBookingRequest{UserID: "me", SeatIDs: []string{"A1"}})
+
+	var invalid *services.ValidationError
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestCreateBooking_LocksRepeatedSeatOnce(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+	mockBookingRepo := mocks.NewBookingRepository(t)
+	mockSeatCache := mocks.NewSeatCache(t)
+	mockCache := mocks.NewCache(t)
+
+	service := services.NewBookingService(mockSeatRepo, mockBookingRepo, mockSeatCache, mockCache)
+
+	userID, eventID, seatID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(&domain.Seat{ID: seatID, EventID: eventID, Status: domain.SeatAvailable, Version: 1}, nil).Once()
+	mockSeatRepo.On("LockSeat", mock.Anything, seatID, mock.AnythingOfType("uuid.UUID"), userID, 1).Return(nil).Once()
+	mockBookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(b *domain.Booking) bool {
+		return len(b.Items) == 1
+	})).Return(nil)
+	mockSeatCache.On("RemoveSeats", mock.Anything, eventID, []uuid.UUID{seatID}).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := service.CreateBooking(context.Background(), services.CreateBookingRequest{
+		UserID:  userID.String(),
+		EventID: eventID.String(),
+		SeatIDs: []string{seatID.String(), seatID.String()},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 100000.0, resp.TotalAmount)
+}
+
+func TestCreateBooking_SeatOfAnotherEventIsInvalid(t *testing.T) {
+	mockSeatRepo := mocks.NewSeatRepository(t)
+
+	service := services.NewBookingService(mockSeatRepo, mocks.NewBookingRepository(t), mocks.NewSeatCache(t), mocks.NewCache(t))
+
+	seatID := uuid.New()
+	mockSeatRepo.On("GetByID", mock.Anything, seatID).Return(&domain.Seat{ID: seatID, EventID: uuid.New(), Status: domain.SeatBooked}, nil)
+
+	_, err := service.CreateBooking(context.Background(), services.CreateBookingRequest{
+		UserID:  uuid.NewString(),
+		EventID: uuid.NewString(),
+		SeatIDs: []string{seatID.String()},
+	})
+
+	var invalid *services.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, "seat_ids", invalid.Fields[0].Field)
+}
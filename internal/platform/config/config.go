@@ -0,0 +1,525 @@
+// Package config loads the service configuration. Sources are applied in
+// order, each overriding the previous one:
+//
+//  1. defaults (Default)
+//  2. a YAML file given by -config or CONFIG_FILE
+//  3. environment variables named by the env tags
+//  4. command-line flags named after the YAML path, e.g. -database.max-open-conns
+//
+// Fields tagged secret are redacted whenever the config is printed.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	StorageDriver string `yaml:"storage_driver" env:"STORAGE_DRIVER"`
+	CacheDriver   string `yaml:"cache_driver" env:"CACHE_DRIVER"`
+
+	// ShutdownTimeout bounds stopping everything after a signal: draining and
+	// shutting down HTTP and gRPC, stopping the workers and closing connections.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Booking   BookingConfig   `yaml:"booking"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Log       LogConfig       `yaml:"log"`
+	CORS      CORSConfig      `yaml:"cors"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+}
+
+type HTTPConfig struct {
+	Addr            string        `yaml:"addr" env:"HTTP_ADDR"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// InternalAddr serves /metrics and /debug/vars apart from the public API,
+	// for scrapers inside the cluster; empty turns it off.
+	InternalAddr string `yaml:"internal_addr" env:"HTTP_INTERNAL_ADDR"`
+	// DrainDelay is how long /readyz reports draining before the server stops
+	// accepting connections, so load balancers can take the pod out first.
+	DrainDelay time.Duration `yaml:"drain_delay" env:"HTTP_DRAIN_DELAY"`
+	// RequestTimeout bounds API handlers; BookingTimeout replaces it for
+	// booking creation, which may wait on several seat locks.
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"HTTP_REQUEST_TIMEOUT"`
+	BookingTimeout time.Duration `yaml:"booking_timeout" env:"HTTP_BOOKING_TIMEOUT"`
+	MaxBodyBytes   int           `yaml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES"`
+	// LegacySunset is the date, as YYYY-MM-DD, after which the unversioned
+	// routes may be removed. It is announced in their Sunset header; empty
+	// leaves the header out.
+	LegacySunset string `yaml:"legacy_sunset" env:"HTTP_LEGACY_SUNSET"`
+	// AdminToken is the bearer token the /admin routes require. Empty
+	// closes them.
+	AdminToken string `yaml:"admin_token" env:"HTTP_ADMIN_TOKEN" secret:"true"`
+}
+
+// LegacySunsetDate parses LegacySunset, returning the zero time when it is
+// empty. Validate rejects values it cannot parse.
+func (c HTTPConfig) LegacySunsetDate() time.Time {
+	sunset, _ := time.Parse(time.DateOnly, c.LegacySunset)
+	return sunset
+}
+
+type GRPCConfig struct {
+	// Addr is where the gRPC API listens; empty turns it off.
+	Addr string `yaml:"addr" env:"GRPC_ADDR"`
+	// WatchInterval is how often the poller shared by an event's WatchSeats
+	// streams looks for seat changes.
+	WatchInterval time.Duration `yaml:"watch_interval" env:"GRPC_WATCH_INTERVAL"`
+	// ShutdownTimeout bounds the graceful stop once HTTP is down; calls still
+	// running after it are cut off.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"GRPC_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnectRetries  int           `yaml:"connect_retries" env:"DB_CONNECT_RETRIES"`
+	RetryInterval   time.Duration `yaml:"retry_interval" env:"DB_RETRY_INTERVAL"`
+}
+
+type RedisConfig struct {
+	Host     string `yaml:"host" env:"REDIS_HOST"`
+	Port     int    `yaml:"port" env:"REDIS_PORT"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+type BookingConfig struct {
+	HoldDuration       time.Duration `yaml:"hold_duration" env:"BOOKING_HOLD_DURATION"`
+	CleanupInterval    time.Duration `yaml:"cleanup_interval" env:"BOOKING_CLEANUP_INTERVAL"`
+	ReservationWriters int           `yaml:"reservation_writers" env:"RESERVATION_WRITERS"`
+	ReconcileInterval  time.Duration `yaml:"reconcile_interval" env:"RESERVATION_RECONCILE_INTERVAL"`
+}
+
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName  string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	File         string `yaml:"file" env:"TRACING_FILE"`
+}
+
+// CORSConfig controls cross-origin browser access. List values are
+// comma-separated in env vars and flags. No allowed origins disables CORS.
+type CORSConfig struct {
+	AllowedOrigins []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	MaxAge         time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+// RateLimitConfig sets the request limits on the booking endpoints, over HTTP
+// and gRPC alike. Each limit allows that many requests per window; 0 turns the
+// limit off.
+type RateLimitConfig struct {
+	BookingPerUserEvent       int           `yaml:"booking_per_user_event" env:"RATE_LIMIT_BOOKING_PER_USER_EVENT"`
+	BookingPerUserEventWindow time.Duration `yaml:"booking_per_user_event_window" env:"RATE_LIMIT_BOOKING_PER_USER_EVENT_WINDOW"`
+	BookingPerEvent           int           `yaml:"booking_per_event" env:"RATE_LIMIT_BOOKING_PER_EVENT"`
+	BookingPerEventWindow     time.Duration `yaml:"booking_per_event_window" env:"RATE_LIMIT_BOOKING_PER_EVENT_WINDOW"`
+	SeatReadsPerIP            int           `yaml:"seat_reads_per_ip" env:"RATE_LIMIT_SEAT_READS_PER_IP"`
+	SeatReadsPerIPWindow      time.Duration `yaml:"seat_reads_per_ip_window" env:"RATE_LIMIT_SEAT_READS_PER_IP_WINDOW"`
+	// ClientIPHeader is a header set by a trusted proxy, e.g.
+	// X-Forwarded-For. Only set it behind a proxy that overwrites or appends
+	// to it, or clients can pick their own address.
+	ClientIPHeader string `yaml:"client_ip_header" env:"RATE_LIMIT_CLIENT_IP_HEADER"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// Default returns the values the service used before it was configurable.
+func Default() Config {
+	return Config{
+		StorageDriver: "postgres",
+		CacheDriver:   "redis",
+
+		ShutdownTimeout: 20 * time.Second,
+
+		HTTP: HTTPConfig{
+			Addr:            ":8080",
+			InternalAddr:    ":8081",
+			ReadTimeout:     5 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+			DrainDelay:      5 * time.Second,
+			RequestTimeout:  5 * time.Second,
+			BookingTimeout:  8 * time.Second,
+			MaxBodyBytes:    1 << 20,
+		},
+		GRPC: GRPCConfig{
+			Addr:            ":9090",
+			WatchInterval:   time.Second,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "scalable_ticket",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnectRetries:  10,
+			RetryInterval:   2 * time.Second,
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: 6379,
+		},
+		Booking: BookingConfig{
+			HoldDuration:       10 * time.Minute,
+			CleanupInterval:    1 * time.Minute,
+			ReservationWriters: 8,
+			ReconcileInterval:  30 * time.Second,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "scalable-ticket",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "X-User-ID", "X-Request-ID", "If-None-Match", "traceparent", "tracestate"},
+			ExposedHeaders: []string{"X-Request-ID", "ETag", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"},
+			MaxAge:         10 * time.Minute,
+		},
+		RateLimit: RateLimitConfig{
+			BookingPerUserEvent:       10,
+			BookingPerUserEventWindow: time.Minute,
+			BookingPerEvent:           1000,
+			BookingPerEventWindow:     time.Second,
+			SeatReadsPerIP:            60,
+			SeatReadsPerIPWindow:      10 * time.Second,
+		},
+	}
+}
+
+// Load builds the config from every source and validates it. args are the
+// command-line arguments without the program name; the arguments left after
+// the flags (such as a subcommand) are returned.
+func Load(args []string, getenv func(string) string) (Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", getenv("CONFIG_FILE"), "path to a YAML config file")
+
+	var overrides []flagOverride
+	for _, f := range fields(&cfg) {
+		f := f
+		fs.Func(f.flagName(), "overrides "+f.path+envHint(f.env), func(value string) error {
+			if err := f.set(value); err != nil {
+				return err
+			}
+
+			overrides = append(overrides, flagOverride{field: f, value: value})
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, nil, err
+	}
+
+	// The flag callbacks wrote into cfg while checking the values. Start over
+	// so the file and environment apply underneath the flags, which are then
+	// replayed into the same fields.
+	cfg = Default()
+
+	if *configFile != "" {
+		if err := loadFile(&cfg, *configFile); err != nil {
+			return Config{}, nil, err
+		}
+	}
+
+	for _, f := range fields(&cfg) {
+		if f.env == "" {
+			continue
+		}
+
+		if value := getenv(f.env); value != "" {
+			if err := f.set(value); err != nil {
+				return Config{}, nil, fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
+	}
+
+	for _, o := range overrides {
+		_ = o.field.set(o.value)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, nil, err
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Validate checks required fields and value ranges.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.StorageDriver == "postgres" || c.StorageDriver == "memory",
+		"storage_driver must be postgres or memory, got %q", c.StorageDriver)
+	check(c.CacheDriver == "redis" || c.CacheDriver == "memory" || c.CacheDriver == "none",
+		"cache_driver must be redis, memory or none, got %q", c.CacheDriver)
+
+	check(c.HTTP.Addr != "", "http.addr is required")
+	check(c.HTTP.ReadTimeout > 0, "http.read_timeout must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http.write_timeout must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http.idle_timeout must be positive")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be positive")
+	check(c.HTTP.DrainDelay >= 0, "http.drain_delay must not be negative")
+	check(c.HTTP.RequestTimeout > 0 && c.HTTP.RequestTimeout < c.HTTP.WriteTimeout,
+		"http.request_timeout must be positive and below http.write_timeout")
+	check(c.HTTP.BookingTimeout > 0 && c.HTTP.BookingTimeout < c.HTTP.WriteTimeout,
+		"http.booking_timeout must be positive and below http.write_timeout")
+	check(c.HTTP.MaxBodyBytes >= 1024, "http.max_body_bytes must be at least 1024")
+	if c.HTTP.LegacySunset != "" {
+		_, err := time.Parse(time.DateOnly, c.HTTP.LegacySunset)
+		check(err == nil, "http.legacy_sunset must be a date like 2027-04-30, got %q", c.HTTP.LegacySunset)
+	}
+	check(c.HTTP.AdminToken == "" || len(c.HTTP.AdminToken) >= 16, "http.admin_token must be at least 16 characters")
+	if c.HTTP.InternalAddr != "" {
+		check(c.HTTP.InternalAddr != c.HTTP.Addr, "http.internal_addr must differ from http.addr")
+		check(c.HTTP.InternalAddr != c.GRPC.Addr, "http.internal_addr must differ from grpc.addr")
+	}
+	if c.GRPC.Addr != "" {
+		check(c.GRPC.Addr != c.HTTP.Addr, "grpc.addr must differ from http.addr")
+		check(c.GRPC.WatchInterval > 0, "grpc.watch_interval must be positive")
+		check(c.GRPC.ShutdownTimeout > 0, "grpc.shutdown_timeout must be positive")
+		check(c.HTTP.DrainDelay+c.HTTP.ShutdownTimeout+c.GRPC.ShutdownTimeout < c.ShutdownTimeout,
+			"shutdown_timeout must exceed http.drain_delay plus http.shutdown_timeout plus grpc.shutdown_timeout, leaving time to stop the workers")
+	} else {
+		check(c.HTTP.DrainDelay+c.HTTP.ShutdownTimeout < c.ShutdownTimeout,
+			"shutdown_timeout must exceed http.drain_delay plus http.shutdown_timeout, leaving time to stop the workers")
+	}
+
+	if c.StorageDriver == "postgres" {
+		check(c.Database.Host != "", "database.host is required")
+		check(validPort(c.Database.Port), "database.port must be between 1 and 65535")
+		check(c.Database.User != "", "database.user is required")
+		check(c.Database.Name != "", "database.name is required")
+	}
+
+	check(c.Database.MaxOpenConns >= 1, "database.max_open_conns must be at least 1")
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must be between 0 and max_open_conns")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(c.Database.ConnectRetries >= 1, "database.connect_retries must be at least 1")
+	check(c.Database.RetryInterval > 0, "database.retry_interval must be positive")
+
+	if c.CacheDriver == "redis" {
+		check(c.Redis.Host != "", "redis.host is required")
+		check(validPort(c.Redis.Port), "redis.port must be between 1 and 65535")
+		check(c.Redis.DB >= 0 && c.Redis.DB <= 15, "redis.db must be between 0 and 15")
+	}
+
+	check(c.Booking.HoldDuration >= time.Minute, "booking.hold_duration must be at least 1m")
+	check(c.Booking.CleanupInterval >= time.Second, "booking.cleanup_interval must be at least 1s")
+	check(c.Booking.ReservationWriters >= 1 && c.Booking.ReservationWriters <= 256,
+		"booking.reservation_writers must be between 1 and 256")
+	check(c.Booking.ReconcileInterval >= time.Second, "booking.reconcile_interval must be at least 1s")
+
+	check(c.Tracing.Exporter == "none" || c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout",
+		"tracing.exporter must be none, otlp or stdout, got %q", c.Tracing.Exporter)
+	check(c.Tracing.Exporter == "none" || c.Tracing.ServiceName != "", "tracing.service_name is required")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(origin == "*" || strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://"),
+			"cors.allowed_origins entries must be * or start with http:// or https://, got %q", origin)
+	}
+
+	check(len(c.CORS.AllowedOrigins) == 0 || len(c.CORS.AllowedMethods) > 0,
+		"cors.allowed_methods is required when cors.allowed_origins is set")
+	check(c.CORS.MaxAge >= 0, "cors.max_age must not be negative")
+
+	for _, limit := range []struct {
+		name   string
+		limit  int
+		window time.Duration
+	}{
+		{"booking_per_user_event", c.RateLimit.BookingPerUserEvent, c.RateLimit.BookingPerUserEventWindow},
+		{"booking_per_event", c.RateLimit.BookingPerEvent, c.RateLimit.BookingPerEventWindow},
+		{"seat_reads_per_ip", c.RateLimit.SeatReadsPerIP, c.RateLimit.SeatReadsPerIPWindow},
+	} {
+		check(limit.limit >= 0, "rate_limit.%s must not be negative", limit.name)
+		check(limit.limit == 0 || limit.window >= time.Millisecond,
+			"rate_limit.%s_window must be at least 1ms", limit.name)
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+
+	check(c.Log.Format == "json" || c.Log.Format == "text", "log.format must be json or text, got %q", c.Log.Format)
+
+	if len(problems) > 0 {
+		return errors.New("invalid config: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
+
+// Redacted returns a copy with every non-empty secret replaced.
+func (c Config) Redacted() Config {
+	for _, f := range fields(&c) {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString("******")
+		}
+	}
+
+	return c
+}
+
+// String renders the redacted config as YAML.
+func (c Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("<config: %v>", err)
+	}
+
+	return string(out)
+}
+
+type flagOverride struct {
+	field field
+	value string
+}
+
+// field is one leaf of Config, addressed by its YAML path.
+type field struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.path, "_", "-")
+}
+
+func envHint(env string) string {
+	if env == "" {
+		return ""
+	}
+
+	return " (env " + env + ")"
+}
+
+func (f field) set(raw string) error {
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+
+		f.value.SetInt(int64(n))
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+
+		f.value.SetInt(int64(d))
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+
+		f.value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported config type %s", f.value.Type())
+	}
+
+	return nil
+}
+
+func fields(cfg *Config) []field {
+	return collect(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func collect(v reflect.Value, prefix string) []field {
+	var out []field
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		path := prefix + name
+
+		if sf.Type.Kind() == reflect.Struct {
+			out = append(out, collect(v.Field(i), path+".")...)
+			continue
+		}
+
+		out = append(out, field{
+			path:   path,
+			env:    sf.Tag.Get("env"),
+			secret: sf.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFrom(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestLoad_DefaultsAreValid(t *testing.T) {
+	cfg, rest, err := config.Load(nil, envFrom(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, config.Default(), cfg)
+	assert.Empty(t, rest)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+database:
+  host: file-host
+  port: 6543
+  max_open_conns: 40
+booking:
+  hold_duration: 15m
+`), 0o600))
+
+	cfg, rest, err := config.Load(
+		[]string{"-database.max-open-conns", "50", "migrate", "up"},
+		envFrom(map[string]string{
+			"CONFIG_FILE":       path,
+			"DB_HOST":           "env-host",
+			"DB_MAX_OPEN_CONNS": "45",
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "env-host", cfg.Database.Host, "env overrides file")
+	assert.Equal(t, 6543, cfg.Database.Port, "file overrides defaults")
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns, "flags override env")
+	assert.Equal(t, 15*time.Minute, cfg.Booking.HoldDuration)
+	assert.Equal(t, "postgres", cfg.Database.User, "untouched fields keep defaults")
+	assert.Equal(t, []string{"migrate", "up"}, rest)
+}
+
+func TestLoad_ParsesLists(t *testing.T) {
+	cfg, _, err := config.Load(
+		[]string{"-cors.allowed-methods", "GET,POST"},
+		envFrom(map[string]string{"CORS_ALLOWED_ORIGINS": "https://shop.example, https://admin.example,"}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://shop.example", "https://admin.example"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, []string{"GET", "POST"}, cfg.CORS.AllowedMethods)
+}
+
+func TestLoad_RejectsInvalidValues(t *testing.T) {
+	_, _, err := config.Load(
+		[]string{"-http.read-timeout", "0s"},
+		envFrom(map[string]string{
+			"DB_PORT": "70000", "CACHE_DRIVER": "memcached", "SHUTDOWN_TIMEOUT": "5s", "HTTP_LEGACY_SUNSET": "next year",
+			"GRPC_WATCH_INTERVAL": "0s", "HTTP_INTERNAL_ADDR": ":8080", "HTTP_ADMIN_TOKEN": "letmein",
+		}),
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "http.read_timeout must be positive")
+	assert.Contains(t, err.Error(), "database.port must be between 1 and 65535")
+	assert.Contains(t, err.Error(), `cache_driver must be redis, memory or none, got "memcached"`)
+	assert.Contains(t, err.Error(), "shutdown_timeout must exceed http.drain_delay plus http.shutdown_timeout")
+	assert.Contains(t, err.Error(), `http.legacy_sunset must be a date like 2027-04-30, got "next year"`)
+	assert.Contains(t, err.Error(), "grpc.watch_interval must be positive")
+	assert.Contains(t, err.Error(), "http.internal_addr must differ from http.addr")
+	assert.Contains(t, err.Error(), "http.admin_token must be at least 16 characters")
+
+	_, _, err = config.Load(nil, envFrom(map[string]string{"BOOKING_HOLD_DURATION": "ten minutes"}))
+	assert.EqualError(t, err, `BOOKING_HOLD_DURATION: invalid duration "ten minutes"`)
+}
+
+func TestLoad_RejectsUnknownFileKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  hostname: typo\n"), 0o600))
+
+	_, _, err := config.Load([]string{"-config", path}, envFrom(nil))
+	assert.ErrorContains(t, err, "hostname")
+}
+
+func TestConfig_StringRedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Password = "hunter2"
+	cfg.HTTP.AdminToken = "correct-horse-battery"
+
+	out := cfg.String()
+
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "correct-horse-battery")
+	assert.Contains(t, out, "password: '******'")
+	assert.Contains(t, out, "hold_duration: 10m0s")
+	assert.Equal(t, "hunter2", cfg.Database.Password, "redacting must not modify the original")
+}
@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/database"
+	"github.com/srgjo27/scalable_ticket/internal/platform/database/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	all, err := database.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	for i, m := range all {
+		assert.Equal(t, i+1, m.Version, "migration versions must be contiguous")
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+}
+
+func TestLoadMigrations_SortsAndPairs(t *testing.T) {
+	all, err := database.LoadMigrations(fstest.MapFS{
+		"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX")},
+		"0002_add_index.down.sql": {Data: []byte("DROP INDEX")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE")},
+		"0001_init.down.sql":      {Data: []byte("DROP TABLE")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []database.Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE", Down: "DROP TABLE"},
+		{Version: 2, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+	}, all)
+}
+
+func TestLoadMigrations_Rejects(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_init.up.sql": {Data: []byte("CREATE TABLE")},
+		},
+		"bad name": {
+			"init.sql": {Data: []byte("CREATE TABLE")},
+		},
+		"two names for one version": {
+			"0001_init.up.sql":    {Data: []byte("CREATE TABLE")},
+			"0001_other.down.sql": {Data: []byte("DROP TABLE")},
+		},
+	}
+
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := database.LoadMigrations(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
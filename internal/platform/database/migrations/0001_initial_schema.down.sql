@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS seat_audit_logs;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS booking_items;
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS event_seats;
+DROP TABLE IF EXISTS pricing_tiers;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS venues;
+
+DROP TYPE IF EXISTS booking_status;
+DROP TYPE IF EXISTS seat_status;
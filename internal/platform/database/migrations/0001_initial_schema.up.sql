@@ -1,7 +1,19 @@
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
-CREATE TYPE seat_status AS ENUM ('AVAILABLE', 'LOCKED', 'BOOKED', 'UNAVAILABLE');
-CREATE TYPE booking_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED', 'EXPIRED', 'FAILED');
+-- Databases created from the old init.sql already have these types.
+DO $$
+BEGIN
+    CREATE TYPE seat_status AS ENUM ('AVAILABLE', 'LOCKED', 'BOOKED', 'UNAVAILABLE');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$
+BEGIN
+    CREATE TYPE booking_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED', 'EXPIRED', 'FAILED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
 
 CREATE TABLE IF NOT EXISTS venues (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -85,16 +97,3 @@ CREATE TABLE IF NOT EXISTS seat_audit_logs (
 CREATE INDEX IF NOT EXISTS idx_seats_event_status ON event_seats(event_id, status);
 CREATE INDEX IF NOT EXISTS idx_bookings_status_expires ON bookings(status, expires_at);
 CREATE INDEX IF NOT EXISTS idx_seats_booking_lock ON event_seats(locked_by_booking_id);
-
-INSERT INTO venues (id, name, address) VALUES 
-('a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'Stadion GBK', 'Jl. Pintu Satu Senayan, Jakarta');
-
-INSERT INTO events (id, venue_id, name, start_time, end_time) VALUES 
-('b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'Coldplay Jakarta', NOW() + INTERVAL '1 month', NOW() + INTERVAL '1 month 4 hours');
-
-INSERT INTO pricing_tiers (id, event_id, name, price) VALUES
-('f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'VIP', 5000000);
-
-INSERT INTO event_seats (id, event_id, tier_id, section, row_number, seat_number, status, version) VALUES
-('c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a01', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'A', '1', '1', 'AVAILABLE', 1),
-('c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a02', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'A', '1', '2', 'AVAILABLE', 1);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_seat_audit_seat_changed;
+
+ALTER TABLE seat_audit_logs DROP COLUMN IF EXISTS booking_id;
@@ -0,0 +1,3 @@
+ALTER TABLE seat_audit_logs ADD COLUMN IF NOT EXISTS booking_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_seat_audit_seat_changed ON seat_audit_logs(seat_id, changed_at DESC);
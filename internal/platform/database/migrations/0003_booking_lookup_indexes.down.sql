@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_booking;
+DROP INDEX IF EXISTS idx_booking_items_booking;
+DROP INDEX IF EXISTS idx_bookings_user_created;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_bookings_user_created ON bookings(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_booking_items_booking ON booking_items(booking_id);
+CREATE INDEX IF NOT EXISTS idx_payments_booking ON payments(booking_id);
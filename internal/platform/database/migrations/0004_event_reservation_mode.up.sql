@@ -0,0 +1 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS reservation_mode VARCHAR(20) NOT NULL DEFAULT 'DATABASE';
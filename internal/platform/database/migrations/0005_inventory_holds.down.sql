@@ -0,0 +1,4 @@
+ALTER TABLE seat_audit_logs DROP COLUMN IF EXISTS hold_id;
+
+DROP TABLE IF EXISTS inventory_hold_seats;
+DROP TABLE IF EXISTS inventory_holds;
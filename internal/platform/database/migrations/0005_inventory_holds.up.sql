@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS inventory_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    release_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_by UUID,
+    closed_at TIMESTAMPTZ,
+    booking_id UUID REFERENCES bookings(id)
+);
+
+CREATE TABLE IF NOT EXISTS inventory_hold_seats (
+    hold_id UUID NOT NULL REFERENCES inventory_holds(id) ON DELETE CASCADE,
+    seat_id UUID NOT NULL REFERENCES event_seats(id) ON DELETE CASCADE,
+    PRIMARY KEY (hold_id, seat_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_holds_event_created ON inventory_holds(event_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_inventory_holds_release ON inventory_holds(release_at) WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS idx_inventory_hold_seats_seat ON inventory_hold_seats(seat_id);
+
+ALTER TABLE seat_audit_logs ADD COLUMN IF NOT EXISTS hold_id UUID;
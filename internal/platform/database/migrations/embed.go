@@ -0,0 +1,14 @@
+// Package migrations holds the numbered schema migrations and the optional
+// demo seed, embedded into the binary.
+//
+// Every version NNNN has a NNNN_name.up.sql and a NNNN_name.down.sql file.
+// Versions are applied in order, each in its own transaction.
+package migrations
+
+import "embed"
+
+//go:embed *.up.sql *.down.sql
+var FS embed.FS
+
+//go:embed seed.sql
+var Seed string
@@ -0,0 +1,18 @@
+-- Demo data for local development. Safe to run more than once.
+
+INSERT INTO venues (id, name, address) VALUES
+('a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'Stadion GBK', 'Jl. Pintu Satu Senayan, Jakarta')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO events (id, venue_id, name, start_time, end_time) VALUES
+('b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'Coldplay Jakarta', NOW() + INTERVAL '1 month', NOW() + INTERVAL '1 month 4 hours')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO pricing_tiers (id, event_id, name, price) VALUES
+('f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'VIP', 5000000)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO event_seats (id, event_id, tier_id, section, row_number, seat_number, status, version) VALUES
+('c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a01', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'A', '1', '1', 'AVAILABLE', 1),
+('c1eebc99-9c0b-4ef8-bb6d-6bb9bd380a02', 'b1eebc99-9c0b-4ef8-bb6d-6bb9bd380a22', 'f1eebc99-9c0b-4ef8-bb6d-6bb9bd380a99', 'A', '1', '2', 'AVAILABLE', 1)
+ON CONFLICT (id) DO NOTHING;
@@ -3,7 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 )
 
@@ -13,6 +13,14 @@ type Config struct {
 	User     string
 	Password string
 	DBName   string
+
+	// Pool and retry settings. Zero values keep database/sql's defaults and
+	// 10 connection attempts 2 seconds apart.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectRetries  int
+	RetryInterval   time.Duration
 }
 
 func NewPostgresDB(cfg Config) (*sql.DB, error) {
@@ -21,23 +29,47 @@ func NewPostgresDB(cfg Config) (*sql.DB, error) {
 
 	var db *sql.DB
 	var err error
-	maxRetries := 10
+
+	maxRetries := cfg.ConnectRetries
+	if maxRetries <= 0 {
+		maxRetries = 10
+	}
+
+	retryInterval := cfg.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 2 * time.Second
+	}
 
 	for i := 1; i <= maxRetries; i++ {
-		log.Printf("Connecting to database (Attempt %d/%d)...", i, maxRetries)
+		slog.Info("Connecting to database", "host", cfg.Host, "attempt", i, "max_attempts", maxRetries)
 		db, err = sql.Open("postgres", connStr)
 		if err == nil {
 			err = db.Ping()
 		}
 
 		if err == nil {
-			log.Println("Database connected successfully!")
+			slog.Info("Database connected")
+			applyPoolSettings(db, cfg)
 			return db, nil
 		}
 
-		log.Printf("Database not ready yet. Waiting 2 seconds...")
-		time.Sleep(2 * time.Second)
+		slog.Warn("Database not ready yet", "error", err, "retry_in", retryInterval.String())
+		time.Sleep(retryInterval)
+	}
+
+	return nil, fmt.Errorf("failed to connect to database: %v", err)
+}
+
+func applyPoolSettings(db *sql.DB, cfg Config) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
 	}
 
-	return nil, fmt.Errorf("gagal konek database: %v", err)
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
 }
@@ -0,0 +1,115 @@
+// Package health tracks whether the process should receive traffic. Liveness
+// only needs the process to answer; readiness runs every registered
+// dependency check and is forced off once the process starts draining.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check returns nil when the dependency it covers is usable.
+type Check func(ctx context.Context) error
+
+const (
+	StatusOK       = "ok"
+	StatusReady    = "ready"
+	StatusNotReady = "not ready"
+	StatusDraining = "draining"
+)
+
+type Report struct {
+	Status string `json:"status"`
+	// Checks maps each check name to "ok" or its error message.
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type Checker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Check
+
+	draining atomic.Bool
+}
+
+// NewChecker returns a checker that gives every check at most timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout, checks: make(map[string]Check)}
+}
+
+// Add registers a readiness check, replacing any check with the same name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+// Drain makes every later readiness call fail. It cannot be undone.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs all checks concurrently and reports whether every one passed.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	if c.draining.Load() {
+		return Report{Status: StatusDraining}, false
+	}
+
+	c.mu.RLock()
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	c.mu.RUnlock()
+
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		c.mu.RLock()
+		check := c.checks[name]
+		c.mu.RUnlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]string, len(names))}
+	ready := true
+
+	for i, name := range names {
+		if results[i] != nil {
+			report.Checks[name] = results[i].Error()
+			ready = false
+			continue
+		}
+
+		report.Checks[name] = StatusOK
+	}
+
+	// Shutdown may have begun while the checks ran.
+	if c.draining.Load() {
+		return Report{Status: StatusDraining, Checks: report.Checks}, false
+	}
+
+	if !ready {
+		report.Status = StatusNotReady
+	}
+
+	return report, ready
+}
@@ -0,0 +1,50 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReady_ReportsEveryCheck(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error { return nil })
+	checker.Add("redis", func(context.Context) error { return errors.New("connection refused") })
+
+	report, ready := checker.Ready(context.Background())
+
+	assert.False(t, ready)
+	assert.Equal(t, health.StatusNotReady, report.Status)
+	assert.Equal(t, map[string]string{"database": "ok", "redis": "connection refused"}, report.Checks)
+}
+
+func TestReady_TimesOutHungCheck(t *testing.T) {
+	checker := health.NewChecker(10 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report, ready := checker.Ready(context.Background())
+
+	assert.False(t, ready)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"])
+}
+
+func TestReady_FailsOnceDraining(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error { return nil })
+
+	_, ready := checker.Ready(context.Background())
+	assert.True(t, ready)
+
+	checker.Drain()
+
+	report, ready := checker.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, health.StatusDraining, report.Status)
+}
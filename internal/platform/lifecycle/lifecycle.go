@@ -0,0 +1,113 @@
+// Package lifecycle starts the long-running parts of the process in order and
+// stops them in reverse, so that nothing is torn down while something
+// registered after it still depends on it.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+)
+
+type component struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// Manager owns the process's components. Register dependencies first: a
+// connection pool before the workers that use it, the workers before the
+// HTTP server that feeds them.
+type Manager struct {
+	components []component
+	started    int
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Add registers a component. start must not block; stop must return once the
+// component has finished, or when ctx expires. Either may be nil.
+func (m *Manager) Add(name string, start, stop func(ctx context.Context) error) {
+	m.components = append(m.components, component{name: name, start: start, stop: stop})
+}
+
+// OnStop registers cleanup, such as closing a connection pool, that only
+// runs at shutdown.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.Add(name, nil, stop)
+}
+
+// Go registers a worker that runs until its context is cancelled. The worker
+// keeps the values of the context given to Start but is only cancelled by
+// Stop, which then waits for run to return.
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+
+	m.Add(name, func(ctx context.Context) error {
+		ctx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+		go func() {
+			defer close(done)
+			run(ctx)
+		}()
+
+		return nil
+	}, func(ctx context.Context) error {
+		cancel()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("still running: %w", ctx.Err())
+		}
+	})
+}
+
+// Start starts the components in registration order. If one fails, those
+// already started are stopped again and the start error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	for _, c := range m.components[m.started:] {
+		if c.start != nil {
+			if err := c.start(ctx); err != nil {
+				err = fmt.Errorf("failed to start %s: %w", c.name, err)
+				return errors.Join(err, m.Stop(ctx))
+			}
+		}
+
+		m.started++
+	}
+
+	return nil
+}
+
+// Stop stops the started components in reverse order. A component that fails
+// or overruns ctx doesn't prevent the rest from stopping; every error is
+// returned joined.
+func (m *Manager) Stop(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+
+	var errs []error
+	for ; m.started > 0; m.started-- {
+		c := m.components[m.started-1]
+		if c.stop == nil {
+			continue
+		}
+
+		start := time.Now()
+		if err := c.stop(ctx); err != nil {
+			logger.ErrorContext(ctx, "Failed to stop component", "component", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.name, err))
+			continue
+		}
+
+		logger.InfoContext(ctx, "Component stopped", "component", c.name, "duration", time.Since(start).String())
+	}
+
+	return errors.Join(errs...)
+}
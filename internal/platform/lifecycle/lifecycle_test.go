@@ -0,0 +1,91 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_StopsInReverseOrder(t *testing.T) {
+	var calls []string
+	record := func(call string) func(context.Context) error {
+		return func(context.Context) error {
+			calls = append(calls, call)
+			return nil
+		}
+	}
+
+	m := lifecycle.NewManager()
+	m.OnStop("db", record("stop db"))
+	m.Add("worker", record("start worker"), record("stop worker"))
+	m.Add("http", record("start http"), record("stop http"))
+
+	require.NoError(t, m.Start(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+
+	assert.Equal(t, []string{"start worker", "start http", "stop http", "stop worker", "stop db"}, calls)
+}
+
+func TestManager_FailedStartStopsStartedComponents(t *testing.T) {
+	var stopped []string
+
+	m := lifecycle.NewManager()
+	m.OnStop("db", func(context.Context) error {
+		stopped = append(stopped, "db")
+		return nil
+	})
+	m.Add("http", func(context.Context) error {
+		return errors.New("address already in use")
+	}, func(context.Context) error {
+		stopped = append(stopped, "http")
+		return nil
+	})
+
+	err := m.Start(context.Background())
+
+	assert.ErrorContains(t, err, "failed to start http: address already in use")
+	assert.Equal(t, []string{"db"}, stopped)
+}
+
+func TestManager_GoCancelsAndWaitsForWorker(t *testing.T) {
+	finished := false
+
+	m := lifecycle.NewManager()
+	m.Go("cleanup", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished = true
+	})
+
+	require.NoError(t, m.Start(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+
+	assert.True(t, finished)
+}
+
+func TestManager_StopContinuesPastStuckWorker(t *testing.T) {
+	closed := false
+
+	m := lifecycle.NewManager()
+	m.OnStop("db", func(context.Context) error {
+		closed = true
+		return nil
+	})
+	m.Go("stuck", func(context.Context) { select {} })
+
+	require.NoError(t, m.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := m.Stop(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failed to stop stuck")
+	assert.True(t, closed)
+}
@@ -0,0 +1,154 @@
+// Package logging builds the service's slog logger and carries a request- or
+// job-scoped logger through context.Context, so every line logged while
+// handling one booking shares its request, user, event and booking IDs.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Config struct {
+	// Level is debug, info, warn or error.
+	Level string
+	// Format is json or text.
+	Format string
+}
+
+// New returns a logger writing to out. Records logged with a context that
+// carries a sampled span also get trace_id and span_id.
+func New(cfg Config, out io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", cfg.Level)
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case "json":
+		handler = slog.NewJSONHandler(out, opts)
+	case "text":
+		handler = slog.NewTextHandler(out, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
+
+	return slog.New(traceHandler{handler}), nil
+}
+
+type scopeKey struct{}
+
+// scope is the logger carried by a context plus the keys already added to it
+// with With.
+type scope struct {
+	logger *slog.Logger
+	keys   map[string]bool
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope{logger: logger})
+}
+
+// FromContext returns the logger carried by ctx, or slog.Default().
+func FromContext(ctx context.Context) *slog.Logger {
+	if s, ok := ctx.Value(scopeKey{}).(scope); ok {
+		return s.logger
+	}
+
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger adds the given key/value pairs to
+// every record, e.g. logging.With(ctx, "booking_id", id). Keys the context
+// logger already has are skipped, so helpers can add an ID their caller may
+// have added without repeating it.
+func With(ctx context.Context, keyValues ...any) context.Context {
+	current, _ := ctx.Value(scopeKey{}).(scope)
+	if current.logger == nil {
+		current.logger = slog.Default()
+	}
+
+	keys := make(map[string]bool, len(current.keys)+len(keyValues)/2)
+	for key := range current.keys {
+		keys[key] = true
+	}
+
+	var args []any
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		key := fmt.Sprint(keyValues[i])
+		if keys[key] {
+			continue
+		}
+
+		keys[key] = true
+		args = append(args, key, keyValues[i+1])
+	}
+
+	if len(args) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, scopeKey{}, scope{logger: current.logger.With(args...), keys: keys})
+}
+
+const maxRequestIDLength = 128
+
+// RequestID returns the caller's request ID when it is safe to log and echo,
+// or a new random one.
+func RequestID(fromCaller string) string {
+	if validRequestID(fromCaller) {
+		return fromCaller
+	}
+
+	return uuid.NewString()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// traceHandler adds the current span's IDs so log lines can be found from a
+// trace and the other way round.
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, record slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && sc.IsSampled() {
+		record.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,77 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/srgjo27/scalable_ticket/internal/platform/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_RejectsUnknownSettings(t *testing.T) {
+	_, err := logging.New(logging.Config{Level: "verbose", Format: "json"}, &bytes.Buffer{})
+	assert.EqualError(t, err, `invalid log level "verbose"`)
+
+	_, err = logging.New(logging.Config{Level: "info", Format: "xml"}, &bytes.Buffer{})
+	assert.EqualError(t, err, `invalid log format "xml"`)
+}
+
+func TestWith_CarriesIDsOnce(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(logging.Config{Level: "info", Format: "json"}, &out)
+	require.NoError(t, err)
+
+	ctx := logging.WithLogger(context.Background(), logger)
+	ctx = logging.With(ctx, "request_id", "req-1", "event_id", "event-1")
+	ctx = logging.With(ctx, "event_id", "event-1", "booking_id", "booking-1")
+
+	logging.FromContext(ctx).InfoContext(ctx, "Booking created")
+
+	line := out.String()
+	assert.Equal(t, 1, strings.Count(line, `"event_id"`), "keys already carried are not repeated")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(line), &record))
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "booking-1", record["booking_id"])
+}
+
+func TestNew_AddsTraceIDs(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(logging.Config{Level: "info", Format: "json"}, &out)
+	require.NoError(t, err)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.With("request_id", "req-1").InfoContext(ctx, "HTTP request")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", record["span_id"])
+}
+
+func TestFromContext_FallsBackToDefault(t *testing.T) {
+	assert.NotNil(t, logging.FromContext(context.Background()))
+}
+
+func TestRequestID_ReplacesUnsafeValues(t *testing.T) {
+	assert.Equal(t, "lb-7f3a:42", logging.RequestID("lb-7f3a:42"))
+
+	for _, unsafe := range []string{"", "two words", "new\nline", strings.Repeat("a", 129)} {
+		id := logging.RequestID(unsafe)
+		assert.NotEqual(t, unsafe, id)
+		assert.Len(t, id, 36, "a generated UUID")
+	}
+}